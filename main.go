@@ -1,68 +1,77 @@
 package main
 
 import (
-	"log"
+	"flag"
+	"os"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/widget"
 
+	"github.com/bruno-domazet/go-media-posters/poster"
 	"github.com/bruno-domazet/go-media-posters/tree"
 	"github.com/bruno-domazet/go-media-posters/ui"
 )
 
 func main() {
+	posterProvider := flag.String("poster-provider", envOr("GMP_POSTER_PROVIDER", "filesystem"), "remote poster provider to fall back on when a local poster isn't found: \"filesystem\" or \"tmdb\"")
+	tmdbBaseURL := flag.String("tmdb-base-url", envOr("GMP_TMDB_BASE_URL", "https://api.themoviedb.org/3"), "TMDb-compatible API base URL (used when -poster-provider=tmdb)")
+	tmdbAPIKey := flag.String("tmdb-api-key", os.Getenv("GMP_TMDB_API_KEY"), "API key for -poster-provider=tmdb")
+	flag.Parse()
+
 	a := app.New()
-	win := a.NewWindow("File Tree Browser")
+	win := a.NewWindow("Media Library Browser")
 	win.Resize(fyne.NewSize(1000, 700))
 
 	// Configuration
 	rootPath := "/Users/brdo/repos/private/go-media-posters/assets"
-	cols := 4
 
 	// Create filter to skip unwanted files
 	filter := tree.DefaultFilter()
 
-	var mediaGrid *ui.MediaGrid
-
-	// Progress callback
-	onProgress := func(files, dirs int64) {
-		if mediaGrid != nil {
-			mediaGrid.UpdateProgress(files, dirs)
-		}
-	}
-
-	// Completion callback
-	onComplete := func() {
-		log.Println("Tree loading complete!")
-		if mediaGrid != nil {
-			mediaGrid.Refresh()
+	providers := poster.NewRegistry(poster.NewFilesystemProvider())
+	if *posterProvider == "tmdb" {
+		if *tmdbAPIKey == "" {
+			fyne.LogError("tmdb poster provider requested but -tmdb-api-key/GMP_TMDB_API_KEY is empty", nil)
+		} else {
+			providers.Register(poster.NewHTTPProvider(*tmdbBaseURL, *tmdbAPIKey))
 		}
 	}
 
-	// Load tree asynchronously with filter
-	fileTree, err := tree.LoadAsync(rootPath, filter, onProgress, onComplete)
+	// Persist directory listings to disk so relaunching doesn't redo the full
+	// recursive walk; fall back to the package's in-memory default if the cache
+	// directory can't be created.
+	cache, err := tree.NewFileCacheBackend("")
 	if err != nil {
-		log.Fatalf("Failed to load tree: %v", err)
+		fyne.LogError("main: falling back to in-memory cache", err)
 	}
 
-	// Create UI with new MediaGrid
-	mediaGrid = ui.NewMediaGrid(fileTree, cols, win)
+	// Workspace owns one tab (Tree + MediaGrid) per loaded library root, and lets the
+	// user add more at runtime via the DocTabs "+" button.
+	workspace := ui.NewLibraryWorkspace([]string{rootPath}, *filter, cache, win, providers)
 
 	// Create container with instructions
 	content := container.NewBorder(
 		widget.NewLabel("Arrow Keys: navigate | Enter: open | Backspace: go up | Click: select"),
 		nil, nil, nil,
-		mediaGrid,
+		workspace.CanvasObject(),
 	)
 
 	win.SetContent(content)
 
-	// Set up keyboard shortcuts
+	// Keyboard input always routes to the active tab's grid
 	win.Canvas().SetOnTypedKey(func(key *fyne.KeyEvent) {
-		mediaGrid.TypedKey(key)
+		workspace.TypedKey(key)
 	})
 
 	win.ShowAndRun()
 }
+
+// envOr returns the named environment variable, or def if it's unset or empty.
+func envOr(name, def string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return def
+}
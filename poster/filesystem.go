@@ -0,0 +1,50 @@
+package poster
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/bruno-domazet/go-media-posters/tree"
+)
+
+// defaultPosterNames covers the poster/folder .jpg/.jpeg/.png subset of
+// tree.DefaultMediaPolicy's folder-poster convention (fanart and .webp/.avif names are
+// not checked), so a library that gained a poster.jpg/folder.jpg after its Tree was
+// last loaded can still pick it up through this provider without a reload.
+var defaultPosterNames = []string{"poster.jpg", "poster.jpeg", "poster.png", "folder.jpg", "folder.jpeg", "folder.png"}
+
+// FilesystemProvider looks up a poster already sitting next to node on disk: either
+// node.PosterPath if the Tree already resolved one, or one of Names in node's
+// directory otherwise.
+type FilesystemProvider struct {
+	Names []string
+}
+
+// NewFilesystemProvider returns a FilesystemProvider using the repo's default poster
+// filenames.
+func NewFilesystemProvider() *FilesystemProvider {
+	return &FilesystemProvider{Names: defaultPosterNames}
+}
+
+func (p *FilesystemProvider) Name() string { return "filesystem" }
+
+// Lookup never touches the network; ctx is accepted only to satisfy Provider.
+func (p *FilesystemProvider) Lookup(_ context.Context, node *tree.Node) (io.ReadCloser, error) {
+	if node.PosterPath != "" {
+		return os.Open(node.PosterPath)
+	}
+
+	dir := node.Path
+	if !node.IsDir {
+		dir = filepath.Dir(node.Path)
+	}
+
+	for _, name := range p.Names {
+		if f, err := os.Open(filepath.Join(dir, name)); err == nil {
+			return f, nil
+		}
+	}
+	return nil, os.ErrNotExist
+}
@@ -0,0 +1,253 @@
+package poster
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/bruno-domazet/go-media-posters/tree"
+)
+
+// filenamePattern pulls a bare title and an optional release year or season marker out
+// of a media filename, e.g. "The.Matrix.1999.1080p.mkv" -> title="The Matrix" year=1999,
+// or "Show Name S02E04.mkv" -> title="Show Name" season=S02.
+var filenamePattern = regexp.MustCompile(`(?i)^(?P<title>.+?)[. _-]+(?:\((?P<year>\d{4})\)|(?P<year2>\d{4})[. _-]|(?P<season>S\d{2}))`)
+
+// HTTPProvider looks up a poster from a TMDb-style JSON API: GET /configuration once
+// for the image base URL, then GET /search/movie?query=&year= per title, downloading
+// and disk-caching the first result's poster.
+type HTTPProvider struct {
+	BaseURL    string
+	APIKey     string
+	HTTPClient *http.Client
+
+	// CacheDir holds downloaded posters, keyed by sha1(node.Path)+".jpg". Empty uses
+	// $XDG_CACHE_HOME/go-media-posters (or os.UserCacheDir()/go-media-posters).
+	CacheDir string
+
+	configOnce sync.Once
+	imageBase  string
+	configErr  error
+}
+
+// NewHTTPProvider returns an HTTPProvider querying baseURL (e.g.
+// "https://api.themoviedb.org/3") with apiKey.
+func NewHTTPProvider(baseURL, apiKey string) *HTTPProvider {
+	return &HTTPProvider{BaseURL: strings.TrimRight(baseURL, "/"), APIKey: apiKey, HTTPClient: http.DefaultClient}
+}
+
+func (p *HTTPProvider) Name() string { return "http" }
+
+// Lookup parses a title (and year, if present) out of node's filename, resolves it
+// against the API, and returns the downloaded poster, caching it on disk for next time.
+func (p *HTTPProvider) Lookup(ctx context.Context, node *tree.Node) (io.ReadCloser, error) {
+	if node.IsDir {
+		return nil, fmt.Errorf("poster: http provider does not look up directories")
+	}
+
+	cachePath, cacheErr := p.cachePathFor(node.Path)
+	if cacheErr == nil {
+		if f, err := os.Open(cachePath); err == nil {
+			return f, nil
+		}
+	}
+
+	title, year := parseFilename(node.Name)
+	if title == "" {
+		return nil, fmt.Errorf("poster: could not parse a title from %q", node.Name)
+	}
+
+	imageBase, err := p.imageBaseURL(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	posterPath, err := p.searchMovie(ctx, title, year)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := p.download(ctx, imageBase+posterPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if cacheErr == nil {
+		if err := os.MkdirAll(filepath.Dir(cachePath), 0o755); err == nil {
+			_ = os.WriteFile(cachePath, data, 0o644)
+		}
+	}
+
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// cachePathFor returns the on-disk cache path for the poster of the file at path.
+func (p *HTTPProvider) cachePathFor(path string) (string, error) {
+	dir := p.CacheDir
+	if dir == "" {
+		var err error
+		dir, err = defaultCacheDir()
+		if err != nil {
+			return "", err
+		}
+	}
+	sum := sha1.Sum([]byte(path))
+	return filepath.Join(dir, hex.EncodeToString(sum[:])+".jpg"), nil
+}
+
+func defaultCacheDir() (string, error) {
+	if dir := os.Getenv("XDG_CACHE_HOME"); dir != "" {
+		return filepath.Join(dir, "go-media-posters"), nil
+	}
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "go-media-posters"), nil
+}
+
+// tmdbConfig is the subset of GET /configuration this provider needs.
+type tmdbConfig struct {
+	Images struct {
+		SecureBaseURL string   `json:"secure_base_url"`
+		PosterSizes   []string `json:"poster_sizes"`
+	} `json:"images"`
+}
+
+// imageBaseURL fetches and caches /configuration's image base URL, preferring a "w500"
+// poster size when the API offers one.
+func (p *HTTPProvider) imageBaseURL(ctx context.Context) (string, error) {
+	p.configOnce.Do(func() {
+		var cfg tmdbConfig
+		p.configErr = p.getJSON(ctx, p.BaseURL+"/configuration", nil, &cfg)
+		if p.configErr != nil {
+			return
+		}
+
+		size := "w500"
+		found := false
+		for _, s := range cfg.Images.PosterSizes {
+			if s == size {
+				found = true
+				break
+			}
+		}
+		if !found && len(cfg.Images.PosterSizes) > 0 {
+			size = cfg.Images.PosterSizes[len(cfg.Images.PosterSizes)-1]
+		}
+		p.imageBase = cfg.Images.SecureBaseURL + size
+	})
+	return p.imageBase, p.configErr
+}
+
+// tmdbSearchResult is the subset of GET /search/movie this provider needs.
+type tmdbSearchResult struct {
+	Results []struct {
+		PosterPath string `json:"poster_path"`
+	} `json:"results"`
+}
+
+func (p *HTTPProvider) searchMovie(ctx context.Context, title, year string) (string, error) {
+	q := url.Values{"query": {title}}
+	if year != "" {
+		q.Set("year", year)
+	}
+
+	var res tmdbSearchResult
+	if err := p.getJSON(ctx, p.BaseURL+"/search/movie", q, &res); err != nil {
+		return "", err
+	}
+	for _, r := range res.Results {
+		if r.PosterPath != "" {
+			return r.PosterPath, nil
+		}
+	}
+	return "", fmt.Errorf("poster: no results for %q", title)
+}
+
+func (p *HTTPProvider) getJSON(ctx context.Context, endpoint string, query url.Values, out any) error {
+	u := endpoint
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("api_key", p.APIKey)
+	u += "?" + query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := p.httpClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("poster: %s: unexpected status %s", endpoint, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func (p *HTTPProvider) download(ctx context.Context, imageURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("poster: %s: unexpected status %s", imageURL, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func (p *HTTPProvider) httpClient() *http.Client {
+	if p.HTTPClient != nil {
+		return p.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// parseFilename extracts a human-readable title and release year from a media
+// filename via filenamePattern, turning each dot in the title into a space (other
+// separators such as '_' are left as they are).
+func parseFilename(name string) (title, year string) {
+	base := strings.TrimSuffix(name, filepath.Ext(name))
+
+	m := filenamePattern.FindStringSubmatch(base)
+	if m == nil {
+		return strings.TrimSpace(strings.ReplaceAll(base, ".", " ")), ""
+	}
+
+	groups := make(map[string]string, len(m))
+	for i, g := range filenamePattern.SubexpNames() {
+		if g != "" && i < len(m) {
+			groups[g] = m[i]
+		}
+	}
+
+	title = strings.TrimSpace(strings.ReplaceAll(groups["title"], ".", " "))
+	year = groups["year"]
+	if year == "" {
+		year = groups["year2"]
+	}
+	return title, year
+}
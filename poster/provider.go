@@ -0,0 +1,51 @@
+// Package poster looks up poster images for tree.Nodes that local folder
+// conventions didn't already resolve one for (node.PosterPath == ""), via a
+// prioritized chain of Providers: typically a FilesystemProvider first, falling
+// back to an HTTPProvider backed by a TMDb-style API.
+package poster
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/bruno-domazet/go-media-posters/tree"
+)
+
+// ErrNoPoster is returned by Registry.Lookup when no provider produced a poster.
+var ErrNoPoster = errors.New("poster: no provider found a poster for this node")
+
+// Provider looks up a poster image for node, returning the (caller-closed) encoded
+// image data on success.
+type Provider interface {
+	Name() string
+	Lookup(ctx context.Context, node *tree.Node) (io.ReadCloser, error)
+}
+
+// Registry tries a chain of Providers in order, returning the first poster found.
+type Registry struct {
+	providers []Provider
+}
+
+// NewRegistry builds a Registry trying providers in the given order.
+func NewRegistry(providers ...Provider) *Registry {
+	return &Registry{providers: providers}
+}
+
+// Register appends p to the end of the lookup chain.
+func (r *Registry) Register(p Provider) {
+	r.providers = append(r.providers, p)
+}
+
+// Lookup tries each registered provider in turn, returning the first poster found.
+// It returns ErrNoPoster whenever no provider succeeds; the individual providers'
+// errors are not returned.
+func (r *Registry) Lookup(ctx context.Context, node *tree.Node) (io.ReadCloser, error) {
+	for _, p := range r.providers {
+		rc, err := p.Lookup(ctx, node)
+		if err == nil {
+			return rc, nil
+		}
+	}
+	return nil, ErrNoPoster
+}
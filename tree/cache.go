@@ -0,0 +1,229 @@
+package tree
+
+import (
+	"encoding/gob"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+)
+
+// childRecord is the persisted snapshot of a single directory entry, enough to
+// rebuild a *Node without touching disk again.
+type childRecord struct {
+	Name       string
+	IsDir      bool
+	IsVideo    bool
+	PosterPath string
+	Kind       MediaKind
+}
+
+// dirCacheEntry is what a CacheBackend stores for one directory path. ModTime and
+// Size describe the directory itself (not its contents) and are compared against a
+// fresh os.Stat to decide whether Children is still valid.
+type dirCacheEntry struct {
+	ModTime  time.Time
+	Size     int64
+	Children []childRecord
+}
+
+// CacheBackend persists directory listings keyed by absolute directory path. The
+// in-memory TTL cache and the on-disk gob index are both implementations of it.
+type CacheBackend interface {
+	Get(path string) (dirCacheEntry, bool)
+	Set(path string, entry dirCacheEntry)
+	Delete(path string)
+}
+
+// ttlCacheBackend is a CacheBackend backed by an in-memory TTL cache; entries expire
+// on their own and nothing survives a restart.
+type ttlCacheBackend struct {
+	cache *ttlcache.Cache[string, dirCacheEntry]
+}
+
+// NewTTLCacheBackend returns a CacheBackend that holds entries in memory for ttl.
+func NewTTLCacheBackend(ttl time.Duration) CacheBackend {
+	cache := ttlcache.New[string, dirCacheEntry](
+		ttlcache.WithTTL[string, dirCacheEntry](ttl),
+	)
+	go cache.Start()
+	return &ttlCacheBackend{cache: cache}
+}
+
+func (b *ttlCacheBackend) Get(path string) (dirCacheEntry, bool) {
+	item := b.cache.Get(path)
+	if item == nil {
+		return dirCacheEntry{}, false
+	}
+	return item.Value(), true
+}
+
+func (b *ttlCacheBackend) Set(path string, entry dirCacheEntry) {
+	b.cache.Set(path, entry, ttlcache.DefaultTTL)
+}
+
+func (b *ttlCacheBackend) Delete(path string) {
+	b.cache.Delete(path)
+}
+
+// fileCacheBackend is a CacheBackend backed by a gob-encoded index file, so the
+// recursive walk doesn't have to be redone on every launch. Writes are debounced and
+// flushed to disk as a single atomic rename.
+type fileCacheBackend struct {
+	path string
+
+	mu      sync.Mutex
+	entries map[string]dirCacheEntry
+	dirty   bool
+	timer   *time.Timer
+}
+
+// defaultCacheDir returns $XDG_CACHE_HOME/go-media-posters, falling back to
+// os.UserCacheDir() when XDG_CACHE_HOME isn't set.
+func defaultCacheDir() (string, error) {
+	if dir := os.Getenv("XDG_CACHE_HOME"); dir != "" {
+		return filepath.Join(dir, "go-media-posters"), nil
+	}
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "go-media-posters"), nil
+}
+
+// NewFileCacheBackend returns a CacheBackend backed by a gob index file at path,
+// loading any existing index. An empty path resolves to
+// defaultCacheDir()/index.gob.
+func NewFileCacheBackend(path string) (CacheBackend, error) {
+	if path == "" {
+		dir, err := defaultCacheDir()
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(dir, "index.gob")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+
+	b := &fileCacheBackend{
+		path:    path,
+		entries: make(map[string]dirCacheEntry),
+	}
+
+	if f, err := os.Open(path); err == nil {
+		defer f.Close()
+		_ = gob.NewDecoder(f).Decode(&b.entries) // corrupt/partial index: start empty
+	}
+
+	return b, nil
+}
+
+func (b *fileCacheBackend) Get(path string) (dirCacheEntry, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	entry, ok := b.entries[path]
+	return entry, ok
+}
+
+func (b *fileCacheBackend) Set(path string, entry dirCacheEntry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.entries[path] = entry
+	b.scheduleFlushLocked()
+}
+
+func (b *fileCacheBackend) Delete(path string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.entries, path)
+	b.scheduleFlushLocked()
+}
+
+// scheduleFlushLocked debounces writes so a burst of Set calls during a walk
+// results in one file write instead of thousands. Callers must hold b.mu.
+func (b *fileCacheBackend) scheduleFlushLocked() {
+	b.dirty = true
+	if b.timer != nil {
+		return
+	}
+	b.timer = time.AfterFunc(500*time.Millisecond, func() {
+		b.mu.Lock()
+		b.timer = nil
+		b.mu.Unlock()
+		_ = b.Flush()
+	})
+}
+
+// Flush writes the index to disk immediately via a temp file + rename.
+func (b *fileCacheBackend) Flush() error {
+	b.mu.Lock()
+	if !b.dirty {
+		b.mu.Unlock()
+		return nil
+	}
+	entries := make(map[string]dirCacheEntry, len(b.entries))
+	for k, v := range b.entries {
+		entries[k] = v
+	}
+	b.dirty = false
+	b.mu.Unlock()
+
+	tmp, err := os.CreateTemp(filepath.Dir(b.path), ".index-*.gob")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := gob.NewEncoder(tmp).Encode(entries); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), b.path)
+}
+
+// toChildRecords converts loaded Nodes into their persisted form.
+func toChildRecords(children []*Node) []childRecord {
+	records := make([]childRecord, len(children))
+	for i, c := range children {
+		records[i] = childRecord{
+			Name:       c.Name,
+			IsDir:      c.IsDir,
+			IsVideo:    c.IsVideo,
+			PosterPath: c.PosterPath,
+			Kind:       c.Kind,
+		}
+	}
+	return records
+}
+
+// fromChildRecords rebuilds *Node children under parent from persisted records,
+// registering each in nodeMap.
+func (t *Tree) fromChildRecords(parent *Node, records []childRecord) []*Node {
+	children := make([]*Node, len(records))
+	for i, r := range records {
+		child := &Node{
+			Name:       r.Name,
+			Path:       filepath.Join(parent.Path, r.Name),
+			IsDir:      r.IsDir,
+			Children:   make([]*Node, 0),
+			Parent:     parent,
+			IsVideo:    r.IsVideo,
+			PosterPath: r.PosterPath,
+			Kind:       r.Kind,
+			tokens:     tokenize(r.Name),
+		}
+		children[i] = child
+
+		t.mu.Lock()
+		t.nodeMap[child.Path] = child
+		t.mu.Unlock()
+	}
+	return children
+}
@@ -0,0 +1,80 @@
+package tree
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileCacheBackendRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "index.gob")
+
+	b, err := NewFileCacheBackend(path)
+	if err != nil {
+		t.Fatalf("NewFileCacheBackend: %v", err)
+	}
+
+	entry := dirCacheEntry{
+		ModTime: time.Now().Truncate(time.Second),
+		Size:    1234,
+		Children: []childRecord{
+			{Name: "Movie.mkv", IsDir: false, IsVideo: true, PosterPath: "/lib/Movie-poster.jpg", Kind: KindVideo},
+			{Name: "Extras", IsDir: true, Kind: KindDirectory},
+		},
+	}
+	b.(*fileCacheBackend).Set("/lib/Movie", entry)
+
+	if err := b.(*fileCacheBackend).Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	reloaded, err := NewFileCacheBackend(path)
+	if err != nil {
+		t.Fatalf("NewFileCacheBackend (reload): %v", err)
+	}
+
+	got, ok := reloaded.Get("/lib/Movie")
+	if !ok {
+		t.Fatal("expected entry to survive a reload from disk")
+	}
+	if !got.ModTime.Equal(entry.ModTime) || got.Size != entry.Size {
+		t.Errorf("got %+v, want %+v", got, entry)
+	}
+	if len(got.Children) != len(entry.Children) {
+		t.Fatalf("got %d children, want %d", len(got.Children), len(entry.Children))
+	}
+	for i, c := range got.Children {
+		if c != entry.Children[i] {
+			t.Errorf("child %d = %+v, want %+v", i, c, entry.Children[i])
+		}
+	}
+}
+
+func TestFileCacheBackendDelete(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "index.gob")
+
+	b, err := NewFileCacheBackend(path)
+	if err != nil {
+		t.Fatalf("NewFileCacheBackend: %v", err)
+	}
+	fb := b.(*fileCacheBackend)
+
+	fb.Set("/lib/Movie", dirCacheEntry{Size: 1})
+	fb.Delete("/lib/Movie")
+
+	if _, ok := fb.Get("/lib/Movie"); ok {
+		t.Fatal("expected entry to be gone after Delete")
+	}
+
+	if err := fb.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	reloaded, err := NewFileCacheBackend(path)
+	if err != nil {
+		t.Fatalf("NewFileCacheBackend (reload): %v", err)
+	}
+	if _, ok := reloaded.Get("/lib/Movie"); ok {
+		t.Fatal("expected deleted entry to stay gone across a reload")
+	}
+}
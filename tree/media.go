@@ -0,0 +1,154 @@
+package tree
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// MediaMode selects which media kinds LoadAsync surfaces as regular children.
+// Directories are always surfaced regardless of mode so navigation keeps working.
+type MediaMode int
+
+const (
+	MediaAll MediaMode = iota
+	MediaVideos
+	MediaImages
+)
+
+// Allows reports whether mode permits surfacing a node of kind.
+func (m MediaMode) Allows(kind MediaKind) bool {
+	if kind == KindDirectory {
+		return true
+	}
+	switch m {
+	case MediaVideos:
+		return kind == KindVideo
+	case MediaImages:
+		return kind == KindImage || kind == KindPoster
+	default:
+		return true
+	}
+}
+
+// MediaKind classifies a Node by the MediaPolicy that matched it.
+type MediaKind int
+
+const (
+	KindOther MediaKind = iota
+	KindDirectory
+	KindVideo
+	KindImage
+	KindAudio
+	KindPoster
+)
+
+// MediaPolicy categorizes file extensions and poster-naming conventions. It
+// replaces the package's previously hardcoded video/poster extension checks so a
+// consumer (e.g. an image-gallery app) can reuse the tree package with its own
+// conventions by supplying a Filter with a different Policy.
+type MediaPolicy struct {
+	VideoExts      map[string]bool
+	ImageExts      map[string]bool
+	AudioExts      map[string]bool
+	PosterExts     map[string]bool
+	PosterSuffixes []string // sibling-file suffixes, e.g. "-poster", "-thumb"
+	PosterNames    []string // bare folder-level names, e.g. "poster", "folder"
+}
+
+// DefaultMediaPolicy returns the conventions matching common media libraries
+// (Kodi/Jellyfin/Plex style).
+func DefaultMediaPolicy() MediaPolicy {
+	return MediaPolicy{
+		VideoExts:      extSet(".mkv", ".avi", ".mp4", ".m4v", ".mov", ".webm"),
+		ImageExts:      extSet(".jpg", ".jpeg", ".png", ".webp", ".avif"),
+		AudioExts:      extSet(".mp3", ".flac", ".m4a", ".wav", ".ogg"),
+		PosterExts:     extSet(".jpg", ".jpeg", ".png", ".webp", ".avif"),
+		PosterSuffixes: []string{"-poster", "-thumb"},
+		PosterNames:    []string{"poster", "folder", "fanart"},
+	}
+}
+
+func extSet(exts ...string) map[string]bool {
+	m := make(map[string]bool, len(exts))
+	for _, e := range exts {
+		m[e] = true
+	}
+	return m
+}
+
+// IsVideo reports whether name is a video file under this policy.
+func (p MediaPolicy) IsVideo(name string) bool {
+	return p.VideoExts[strings.ToLower(filepath.Ext(name))]
+}
+
+// IsImage reports whether name is an image file under this policy.
+func (p MediaPolicy) IsImage(name string) bool {
+	return p.ImageExts[strings.ToLower(filepath.Ext(name))]
+}
+
+// IsAudio reports whether name is an audio file under this policy.
+func (p MediaPolicy) IsAudio(name string) bool {
+	return p.AudioExts[strings.ToLower(filepath.Ext(name))]
+}
+
+// IsPosterFile reports whether name matches a sibling poster-suffix convention,
+// e.g. "Movie-poster.jpg".
+func (p MediaPolicy) IsPosterFile(name string) bool {
+	if !p.PosterExts[strings.ToLower(filepath.Ext(name))] {
+		return false
+	}
+	base := strings.ToLower(strings.TrimSuffix(name, filepath.Ext(name)))
+	for _, suffix := range p.PosterSuffixes {
+		if strings.HasSuffix(base, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// TrimPosterSuffix strips a matched poster suffix from name, returning the base
+// name it associates with, e.g. "Movie-poster.jpg" -> "Movie".
+func (p MediaPolicy) TrimPosterSuffix(name string) string {
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	lower := strings.ToLower(base)
+	for _, suffix := range p.PosterSuffixes {
+		if strings.HasSuffix(lower, suffix) {
+			return base[:len(base)-len(suffix)]
+		}
+	}
+	return base
+}
+
+// IsBarePosterName reports whether name is a bare folder-level poster convention,
+// e.g. "poster.jpg" or "folder.jpg".
+func (p MediaPolicy) IsBarePosterName(name string) bool {
+	if !p.PosterExts[strings.ToLower(filepath.Ext(name))] {
+		return false
+	}
+	base := strings.ToLower(strings.TrimSuffix(name, filepath.Ext(name)))
+	for _, n := range p.PosterNames {
+		if base == n {
+			return true
+		}
+	}
+	return false
+}
+
+// Kind classifies name (and isDir) under this policy.
+func (p MediaPolicy) Kind(name string, isDir bool) MediaKind {
+	switch {
+	case isDir:
+		return KindDirectory
+	case p.IsPosterFile(name), p.IsBarePosterName(name):
+		return KindPoster
+	case p.IsVideo(name):
+		return KindVideo
+	case p.IsImage(name):
+		return KindImage
+	case p.IsAudio(name):
+		return KindAudio
+	default:
+		return KindOther
+	}
+}
@@ -0,0 +1,101 @@
+package tree
+
+import "testing"
+
+func TestMediaPolicyKind(t *testing.T) {
+	p := DefaultMediaPolicy()
+
+	cases := []struct {
+		name  string
+		isDir bool
+		want  MediaKind
+	}{
+		{"Movie", true, KindDirectory},
+		{"Movie.mkv", false, KindVideo},
+		{"Movie-poster.jpg", false, KindPoster},
+		{"poster.jpg", false, KindPoster},
+		{"folder.png", false, KindPoster},
+		{"fanart.webp", false, KindPoster},
+		{"Screenshot.png", false, KindImage},
+		{"soundtrack.mp3", false, KindAudio},
+		{"readme.txt", false, KindOther},
+	}
+
+	for _, c := range cases {
+		if got := p.Kind(c.name, c.isDir); got != c.want {
+			t.Errorf("Kind(%q, %v) = %v, want %v", c.name, c.isDir, got, c.want)
+		}
+	}
+}
+
+func TestMediaPolicyIsPosterFile(t *testing.T) {
+	p := DefaultMediaPolicy()
+
+	if !p.IsPosterFile("Movie-poster.jpg") {
+		t.Error("expected Movie-poster.jpg to be a poster file")
+	}
+	if !p.IsPosterFile("Movie-thumb.png") {
+		t.Error("expected Movie-thumb.png to be a poster file")
+	}
+	if p.IsPosterFile("Movie.jpg") {
+		t.Error("did not expect Movie.jpg (no suffix) to be a poster file")
+	}
+	if p.IsPosterFile("Movie-poster.txt") {
+		t.Error("did not expect a non-image extension to be a poster file")
+	}
+}
+
+func TestMediaPolicyTrimPosterSuffix(t *testing.T) {
+	p := DefaultMediaPolicy()
+
+	cases := []struct {
+		name string
+		want string
+	}{
+		{"Movie-poster.jpg", "Movie"},
+		{"Movie-thumb.png", "Movie"},
+		{"Movie.jpg", "Movie"},
+	}
+
+	for _, c := range cases {
+		if got := p.TrimPosterSuffix(c.name); got != c.want {
+			t.Errorf("TrimPosterSuffix(%q) = %q, want %q", c.name, got, c.want)
+		}
+	}
+}
+
+func TestMediaPolicyIsBarePosterName(t *testing.T) {
+	p := DefaultMediaPolicy()
+
+	for _, name := range []string{"poster.jpg", "folder.png", "fanart.webp"} {
+		if !p.IsBarePosterName(name) {
+			t.Errorf("expected %q to be a bare poster name", name)
+		}
+	}
+	if p.IsBarePosterName("Movie.jpg") {
+		t.Error("did not expect Movie.jpg to be a bare poster name")
+	}
+}
+
+func TestMediaModeAllows(t *testing.T) {
+	cases := []struct {
+		mode MediaMode
+		kind MediaKind
+		want bool
+	}{
+		{MediaAll, KindVideo, true},
+		{MediaAll, KindImage, true},
+		{MediaVideos, KindVideo, true},
+		{MediaVideos, KindImage, false},
+		{MediaVideos, KindDirectory, true},
+		{MediaImages, KindImage, true},
+		{MediaImages, KindPoster, true},
+		{MediaImages, KindVideo, false},
+	}
+
+	for _, c := range cases {
+		if got := c.mode.Allows(c.kind); got != c.want {
+			t.Errorf("mode %v Allows(%v) = %v, want %v", c.mode, c.kind, got, c.want)
+		}
+	}
+}
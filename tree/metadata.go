@@ -0,0 +1,167 @@
+package tree
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MediaMetadata holds the metadata a MetadataProvider resolved for a Node.
+type MediaMetadata struct {
+	Title     string
+	Year      int
+	Plot      string
+	Rating    float64
+	ThumbPath string // Poster/thumbnail path, if the provider found one
+	Source    string // Name of the provider that resolved this metadata
+}
+
+// MetadataProvider resolves MediaMetadata for a Node from files next to it on disk.
+// Tree.RegisterProvider adds providers in priority order; the first one to return a
+// non-nil result for a Node wins.
+type MetadataProvider interface {
+	// Name identifies the provider; used as MediaMetadata.Source.
+	Name() string
+	// Lookup returns metadata for node, or nil if this provider has nothing for it.
+	Lookup(node *Node) *MediaMetadata
+}
+
+// sidecarPath returns videoPath with its extension replaced by ext, e.g.
+// "Movie.mkv" + ".nfo" -> "Movie.nfo".
+func sidecarPath(videoPath, ext string) string {
+	return strings.TrimSuffix(videoPath, filepath.Ext(videoPath)) + ext
+}
+
+// nfoKodiXML matches the common subset of Kodi's <movie>/<tvshow>/<episodedetails> NFO
+// schemas; the root element name isn't checked so all three parse the same way.
+type nfoKodiXML struct {
+	Title  string  `xml:"title"`
+	Year   int     `xml:"year"`
+	Plot   string  `xml:"plot"`
+	Rating float64 `xml:"rating"`
+	Thumb  string  `xml:"thumb"`
+}
+
+// nfoProvider resolves Kodi-style "<video>.nfo" XML sidecars.
+type nfoProvider struct{}
+
+// NewNFOProvider returns a MetadataProvider for Kodi-style ".nfo" sidecars.
+func NewNFOProvider() MetadataProvider { return nfoProvider{} }
+
+func (nfoProvider) Name() string { return "nfo" }
+
+func (nfoProvider) Lookup(node *Node) *MediaMetadata {
+	if node.IsDir {
+		return nil
+	}
+
+	path := sidecarPath(node.Path, ".nfo")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var parsed nfoKodiXML
+	if err := xml.Unmarshal(data, &parsed); err != nil {
+		return nil
+	}
+
+	md := &MediaMetadata{
+		Title:  parsed.Title,
+		Year:   parsed.Year,
+		Plot:   parsed.Plot,
+		Rating: parsed.Rating,
+		Source: "nfo",
+	}
+	if parsed.Thumb != "" {
+		md.ThumbPath = resolveSidecarPath(node.Path, parsed.Thumb)
+	}
+	return md
+}
+
+// sidecarJSON matches a "<video>.json" sidecar written by common scrapers/downloaders.
+type sidecarJSON struct {
+	Title  string  `json:"title"`
+	Year   int     `json:"year"`
+	Plot   string  `json:"plot"`
+	Rating float64 `json:"rating"`
+	Thumb  string  `json:"thumb"`
+}
+
+// jsonSidecarProvider resolves "<video>.json" sidecar metadata files.
+type jsonSidecarProvider struct{}
+
+// NewJSONSidecarProvider returns a MetadataProvider for "<video>.json" sidecars.
+func NewJSONSidecarProvider() MetadataProvider { return jsonSidecarProvider{} }
+
+func (jsonSidecarProvider) Name() string { return "json-sidecar" }
+
+func (jsonSidecarProvider) Lookup(node *Node) *MediaMetadata {
+	if node.IsDir {
+		return nil
+	}
+
+	path := sidecarPath(node.Path, ".json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var parsed sidecarJSON
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil
+	}
+
+	md := &MediaMetadata{
+		Title:  parsed.Title,
+		Year:   parsed.Year,
+		Plot:   parsed.Plot,
+		Rating: parsed.Rating,
+		Source: "json-sidecar",
+	}
+	if parsed.Thumb != "" {
+		md.ThumbPath = resolveSidecarPath(node.Path, parsed.Thumb)
+	}
+	return md
+}
+
+// folderPosterNames are checked, in order, inside a directory to find its poster,
+// matching the conventions used by Jellyfin and Plex.
+var folderPosterNames = []string{
+	"poster.jpg", "poster.jpeg", "poster.png", "poster.webp", "poster.avif",
+	"folder.jpg", "folder.jpeg", "folder.png", "folder.webp", "folder.avif",
+	"fanart.jpg", "fanart.jpeg", "fanart.png", "fanart.webp", "fanart.avif",
+}
+
+// folderPosterProvider resolves folder-level poster.jpg/folder.jpg/fanart.jpg files.
+type folderPosterProvider struct{}
+
+// NewFolderPosterProvider returns a MetadataProvider for folder-level poster conventions.
+func NewFolderPosterProvider() MetadataProvider { return folderPosterProvider{} }
+
+func (folderPosterProvider) Name() string { return "folder-poster" }
+
+func (folderPosterProvider) Lookup(node *Node) *MediaMetadata {
+	if !node.IsDir {
+		return nil
+	}
+
+	for _, name := range folderPosterNames {
+		path := filepath.Join(node.Path, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return &MediaMetadata{ThumbPath: path, Source: "folder-poster"}
+		}
+	}
+	return nil
+}
+
+// resolveSidecarPath resolves a thumb reference from an NFO/JSON sidecar, which may be
+// a bare filename (relative to the video's directory) or an already-absolute path.
+func resolveSidecarPath(videoPath, thumb string) string {
+	if filepath.IsAbs(thumb) {
+		return thumb
+	}
+	return filepath.Join(filepath.Dir(videoPath), thumb)
+}
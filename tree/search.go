@@ -0,0 +1,123 @@
+package tree
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SearchOptions filters and tunes a Tree.Search call.
+type SearchOptions struct {
+	VideosOnly bool   // only match nodes with IsVideo set
+	HasPoster  bool   // only match nodes with a resolved PosterPath
+	MinDepth   int    // only match nodes at least this many levels below Root
+	Ext        string // only match files with this extension, e.g. ".mkv"; empty means any
+	Limit      int    // cap on results; 0 means unlimited
+}
+
+// SearchResult pairs a matched Node with its fuzzy match score (higher is better).
+type SearchResult struct {
+	Node  *Node
+	Score int
+}
+
+// tokenize lowercases name and splits it on common filename separators, producing
+// the inverted-index tokens Search matches against.
+func tokenize(name string) []string {
+	lower := strings.ToLower(name)
+	return strings.FieldsFunc(lower, func(r rune) bool {
+		switch r {
+		case '.', '_', '-', ' ', '(', ')', '[', ']':
+			return true
+		}
+		return false
+	})
+}
+
+// Search walks nodeMap and returns nodes whose name fuzzy-matches query, ranked by
+// score (best first) and filtered by opts. Every node in nodeMap is visited on each
+// call, so the cost grows with the tree's size; each Node's tokens were built once at
+// load time, so only the scoring, not the tokenizing, is repeated per call.
+func (t *Tree) Search(query string, opts SearchOptions) []SearchResult {
+	query = strings.ToLower(strings.TrimSpace(query))
+
+	t.mu.RLock()
+	nodes := make([]*Node, 0, len(t.nodeMap))
+	for _, n := range t.nodeMap {
+		nodes = append(nodes, n)
+	}
+	t.mu.RUnlock()
+
+	results := make([]SearchResult, 0)
+	for _, n := range nodes {
+		if opts.VideosOnly && !n.IsVideo {
+			continue
+		}
+		if opts.HasPoster && n.PosterPath == "" {
+			continue
+		}
+		if opts.Ext != "" && !strings.EqualFold(filepath.Ext(n.Name), opts.Ext) {
+			continue
+		}
+		if opts.MinDepth > 0 && depthOf(n) < opts.MinDepth {
+			continue
+		}
+
+		score, ok := fuzzyScore(query, n)
+		if !ok {
+			continue
+		}
+		results = append(results, SearchResult{Node: n, Score: score})
+	}
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+
+	if opts.Limit > 0 && len(results) > opts.Limit {
+		results = results[:opts.Limit]
+	}
+	return results
+}
+
+// depthOf returns how many Parent links separate n from the root.
+func depthOf(n *Node) int {
+	depth := 0
+	for p := n.Parent; p != nil; p = p.Parent {
+		depth++
+	}
+	return depth
+}
+
+// fuzzyScore scores node against query using its token index, falling back to a
+// plain substring check against the full name. The bool is false when nothing
+// matched at all. An empty query matches everything with score 0.
+func fuzzyScore(query string, node *Node) (int, bool) {
+	if query == "" {
+		return 0, true
+	}
+
+	best := 0
+	matched := false
+	for _, tok := range node.tokens {
+		switch {
+		case tok == query:
+			return 100, true
+		case strings.HasPrefix(tok, query):
+			matched = true
+			if s := 80 - (len(tok) - len(query)); s > best {
+				best = s
+			}
+		case strings.Contains(tok, query):
+			matched = true
+			if s := 50 - (len(tok) - len(query)); s > best {
+				best = s
+			}
+		}
+	}
+
+	if !matched && strings.Contains(strings.ToLower(node.Name), query) {
+		matched = true
+		best = 30
+	}
+
+	return best, matched
+}
@@ -0,0 +1,75 @@
+package tree
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	cases := []struct {
+		name string
+		want []string
+	}{
+		{"The.Matrix.1999.mkv", []string{"the", "matrix", "1999", "mkv"}},
+		{"Some Movie (2020) [1080p]", []string{"some", "movie", "2020", "1080p"}},
+		{"already_lower-case", []string{"already", "lower", "case"}},
+		{"", nil},
+	}
+
+	for _, c := range cases {
+		got := tokenize(c.name)
+		if len(got) == 0 && len(c.want) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("tokenize(%q) = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestFuzzyScore(t *testing.T) {
+	node := &Node{Name: "The Matrix (1999).mkv", tokens: tokenize("The Matrix (1999).mkv")}
+
+	cases := []struct {
+		query     string
+		wantMatch bool
+	}{
+		{"", true},
+		{"matrix", true},
+		{"mat", true},
+		{"atri", true},
+		{"nope", false},
+	}
+
+	for _, c := range cases {
+		score, ok := fuzzyScore(c.query, node)
+		if ok != c.wantMatch {
+			t.Errorf("fuzzyScore(%q) matched = %v, want %v", c.query, ok, c.wantMatch)
+		}
+		if ok && score <= 0 && c.query != "" {
+			t.Errorf("fuzzyScore(%q) = %d, want > 0", c.query, score)
+		}
+	}
+
+	exact, _ := fuzzyScore("matrix", node)
+	prefix, _ := fuzzyScore("mat", node)
+	if exact <= prefix {
+		t.Errorf("exact token match score %d should outrank prefix match score %d", exact, prefix)
+	}
+}
+
+func TestFuzzyScorePrefersPrefixOverSubstring(t *testing.T) {
+	node := &Node{Name: "Screamers.mkv", tokens: tokenize("Screamers.mkv")}
+
+	prefix, ok := fuzzyScore("scream", node)
+	if !ok {
+		t.Fatalf("expected prefix match")
+	}
+	substring, ok := fuzzyScore("ream", node)
+	if !ok {
+		t.Fatalf("expected substring match")
+	}
+	if prefix <= substring {
+		t.Errorf("prefix score %d should outrank substring score %d", prefix, substring)
+	}
+}
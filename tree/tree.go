@@ -1,18 +1,34 @@
 package tree
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/charlievieth/fastwalk"
-	"github.com/jellydator/ttlcache/v3"
 )
 
+// LoadState describes where a Node is in its loading lifecycle.
+type LoadState int32
+
+const (
+	StatePending LoadState = iota
+	StateLoading
+	StateLoaded
+	StateError
+)
+
+// progressThrottle is the minimum interval between onProgress callbacks
+// published while a tree is loading.
+const progressThrottle = 100 * time.Millisecond
+
 // Node represents a file or directory in the tree
 type Node struct {
 	Name       string
@@ -23,6 +39,36 @@ type Node struct {
 	mu         sync.RWMutex
 	IsVideo    bool   // True if this is a video file
 	PosterPath string // Path to poster image if available
+	Kind       MediaKind
+	Metadata   *MediaMetadata
+	state      atomic.Int32
+	tokens     []string // lowercased name tokens, indexed at load time for Tree.Search
+}
+
+// ViewMode controls how Tree.VisibleNodes is derived from CurrentDir.
+type ViewMode int
+
+const (
+	// ViewNormal shows CurrentDir's immediate children, as usual.
+	ViewNormal ViewMode = iota
+	// ViewFlatVideos shows every video below CurrentDir, regardless of nesting.
+	ViewFlatVideos
+)
+
+// State returns the Node's current LoadState.
+func (n *Node) State() LoadState {
+	return LoadState(n.state.Load())
+}
+
+func (n *Node) setState(s LoadState) {
+	n.state.Store(int32(s))
+}
+
+// ProgressEvent describes an incremental update published while the tree loads.
+type ProgressEvent struct {
+	Files       int64
+	Dirs        int64
+	CurrentPath string
 }
 
 // Tree holds the file tree structure
@@ -36,45 +82,85 @@ type Tree struct {
 	isLoading    bool
 	filesFound   atomic.Int64
 	dirsFound    atomic.Int64
-	cache        *ttlcache.Cache[string, []*Node]
+	cache        CacheBackend
 	filter       *Filter
+	ctx          context.Context
+	numWorkers   int
+	onProgress   func(ProgressEvent)
+	progressMu   sync.Mutex
+	lastProgress time.Time
+	providers    []MetadataProvider
+	viewMode     ViewMode
+}
+
+// SetViewMode switches how VisibleNodes is computed and immediately recomputes it.
+func (t *Tree) SetViewMode(m ViewMode) {
+	t.mu.Lock()
+	t.viewMode = m
+	t.mu.Unlock()
+	t.UpdateVisibleNodes()
+}
+
+// ViewMode returns the tree's current ViewMode.
+func (t *Tree) ViewMode() ViewMode {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.viewMode
+}
+
+// RegisterProvider adds a MetadataProvider consulted, in registration order, when
+// resolving metadata for nodes as directories are loaded. Providers registered after
+// the tree starts loading only apply to directories loaded from that point on.
+func (t *Tree) RegisterProvider(p MetadataProvider) {
+	t.mu.Lock()
+	t.providers = append(t.providers, p)
+	t.mu.Unlock()
+}
+
+// SetCacheBackend replaces the tree's CacheBackend. Entries already loaded keep
+// using whatever backend they were stored in until their directory is re-walked.
+func (t *Tree) SetCacheBackend(b CacheBackend) {
+	t.mu.Lock()
+	t.cache = b
+	t.mu.Unlock()
+}
+
+// InvalidateCache drops the cached entry for path, and every cached descendant when
+// recursive is set, from the tree's current CacheBackend.
+func (t *Tree) InvalidateCache(path string, recursive bool) {
+	t.mu.RLock()
+	node, ok := t.nodeMap[path]
+	t.mu.RUnlock()
+	if !ok {
+		t.cache.Delete(path)
+		return
+	}
+	t.invalidateSubtree(node, recursive)
 }
 
 // Filter defines file/directory filtering rules
 type Filter struct {
 	SkipHidden      bool
 	SkipExtensions  map[string]bool
-	MaxChildrenShow int // Max children to show per directory for performance
+	MaxChildrenShow int         // Max children to show per directory for performance
+	Policy          MediaPolicy // Extension/naming conventions for videos, images, posters, ...
+	Mode            MediaMode   // Which media kinds to surface; directories always show
 }
 
-// DefaultFilter returns a filter that skips common unwanted files
+// DefaultFilter returns a filter that skips common unwanted files and surfaces
+// every media kind under DefaultMediaPolicy.
 func DefaultFilter() *Filter {
 	return &Filter{
 		SkipHidden: true,
 		SkipExtensions: map[string]bool{
-			".nfo": true,
-			".png": true,
+			".nfo": true, // read directly by nfoProvider; never shown as a regular child
 		},
 		MaxChildrenShow: 1000, // Limit displayed items for performance
+		Policy:          DefaultMediaPolicy(),
+		Mode:            MediaAll,
 	}
 }
 
-// IsVideoFile checks if a file is a video based on extension
-func IsVideoFile(name string) bool {
-	ext := strings.ToLower(filepath.Ext(name))
-	return ext == ".mkv" || ext == ".avi" || ext == ".mp4" || ext == ".m4v"
-}
-
-// IsPosterFile checks if a file is a poster image
-func IsPosterFile(name string) bool {
-	ext := strings.ToLower(filepath.Ext(name))
-	if ext != ".jpg" && ext != ".jpeg" {
-		return false
-	}
-	return strings.HasSuffix(strings.ToLower(name), "-poster.jpg") ||
-		strings.HasSuffix(strings.ToLower(name), "-poster.jpeg")
-}
-
 // ShouldSkip checks if a file/directory should be skipped
 func (f *Filter) ShouldSkip(name string, isDir bool) bool {
 	// Skip hidden files/directories
@@ -93,9 +179,14 @@ func (f *Filter) ShouldSkip(name string, isDir bool) bool {
 	return false
 }
 
-// LoadAsync loads the directory structure asynchronously using fastwalk with TTL caching
-// It recursively traverses the entire tree structure upfront for optimal navigation performance
-func LoadAsync(rootPath string, filter *Filter, onProgress func(files, dirs int64), onComplete func()) (*Tree, error) {
+// LoadAsync loads the directory structure asynchronously using fastwalk, backed by
+// cache for directory listings. The current directory's immediate children are walked
+// first so the UI can render as soon as possible; the rest of the tree is then streamed
+// in the background across workers, with onProgress published at most once per
+// progressThrottle interval. cache may be nil, which uses a 5-minute in-memory TTL
+// cache (see NewFileCacheBackend for a persistent alternative). Cancel ctx to abort
+// the walk.
+func LoadAsync(ctx context.Context, rootPath string, filter *Filter, cache CacheBackend, onProgress func(ProgressEvent), onComplete func()) (*Tree, error) {
 	info, err := os.Stat(rootPath)
 	if err != nil {
 		return nil, err
@@ -105,6 +196,10 @@ func LoadAsync(rootPath string, filter *Filter, onProgress func(files, dirs int6
 		filter = DefaultFilter()
 	}
 
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
 	root := &Node{
 		Name:     filepath.Base(rootPath),
 		Path:     rootPath,
@@ -113,13 +208,17 @@ func LoadAsync(rootPath string, filter *Filter, onProgress func(files, dirs int6
 		Parent:   nil,
 	}
 
-	// Create TTL cache with 5 minute expiration
-	cache := ttlcache.New[string, []*Node](
-		ttlcache.WithTTL[string, []*Node](5 * time.Minute),
-	)
+	if cache == nil {
+		cache = NewTTLCacheBackend(5 * time.Minute)
+	}
 
-	// Start automatic expired item deletion
-	go cache.Start()
+	numWorkers := runtime.NumCPU()
+	if numWorkers > 8 {
+		numWorkers = 8
+	}
+	if numWorkers < 1 {
+		numWorkers = 1
+	}
 
 	tree := &Tree{
 		Root:        root,
@@ -129,63 +228,177 @@ func LoadAsync(rootPath string, filter *Filter, onProgress func(files, dirs int6
 		isLoading:   true,
 		cache:       cache,
 		filter:      filter,
+		ctx:         ctx,
+		numWorkers:  numWorkers,
+		onProgress:  onProgress,
 	}
 
 	// Add root to map
 	tree.nodeMap[rootPath] = root
 
-	// Recursively load entire tree structure in background
+	// Register the built-in metadata providers, in priority order. Consumers can
+	// call RegisterProvider to add remote lookups (TMDB/OMDB), consulted after these.
+	tree.RegisterProvider(NewNFOProvider())
+	tree.RegisterProvider(NewJSONSidecarProvider())
+	tree.RegisterProvider(NewFolderPosterProvider())
+
 	go func() {
-		log.Printf("Starting recursive tree traversal from: %s", rootPath)
-		children := tree.loadDirectory(root, true) // recursive=true
+		log.Printf("Starting streaming tree traversal from: %s", rootPath)
+
+		// Load the current directory's immediate children first so the UI can
+		// render right away instead of waiting for the full recursive walk.
+		children := tree.loadDirectory(ctx, root, false)
 		root.mu.Lock()
 		root.Children = children
 		root.mu.Unlock()
+		root.setState(StateLoaded)
+
+		tree.UpdateVisibleNodes()
+		if onComplete != nil {
+			onComplete()
+		}
+
+		// Stream the rest of the tree into the background, fanning out across
+		// subtrees, so nested directories are warm in cache by the time the
+		// user navigates into them.
+		tree.loadSubtreesAsync(ctx, root, children)
 
 		tree.mu.Lock()
 		tree.isLoading = false
 		tree.mu.Unlock()
 
-		tree.UpdateVisibleNodes()
-
 		log.Printf("Tree traversal complete: %d files, %d dirs", tree.filesFound.Load(), tree.dirsFound.Load())
 
-		if onComplete != nil {
-			onComplete()
-		}
-
-		if onProgress != nil {
-			onProgress(tree.filesFound.Load(), tree.dirsFound.Load())
-		}
+		tree.publishProgress(root.Path, true)
 	}()
 
 	tree.UpdateVisibleNodes()
 	return tree, nil
 }
 
-// loadDirectory loads the children of a directory, using cache if available
-// If recursive=true, it will traverse all subdirectories and pre-load the entire tree
-func (t *Tree) loadDirectory(node *Node, recursive bool) []*Node {
+// loadSubtreesAsync recursively loads children's subtrees in parallel. Each call loads
+// at most tree.numWorkers of its own children at a time; every nested level gets its
+// own limit, so the total number of directories loading at once is not capped.
+func (t *Tree) loadSubtreesAsync(ctx context.Context, parent *Node, children []*Node) {
+	sem := make(chan struct{}, t.numWorkers)
+	var wg sync.WaitGroup
+
+	for _, child := range children {
+		if !child.IsDir {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		default:
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(child *Node) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			grandchildren := t.loadDirectory(ctx, child, false)
+			child.mu.Lock()
+			child.Children = grandchildren
+			child.mu.Unlock()
+			child.setState(StateLoaded)
+
+			t.publishProgress(child.Path, false)
+
+			t.loadSubtreesAsync(ctx, child, grandchildren)
+		}(child)
+	}
+
+	wg.Wait()
+}
+
+// publishProgress invokes onProgress, throttled to progressThrottle unless force is set.
+func (t *Tree) publishProgress(currentPath string, force bool) {
+	if t.onProgress == nil {
+		return
+	}
+
+	now := time.Now()
+	t.progressMu.Lock()
+	if !force && now.Sub(t.lastProgress) < progressThrottle {
+		t.progressMu.Unlock()
+		return
+	}
+	t.lastProgress = now
+	t.progressMu.Unlock()
+
+	t.onProgress(ProgressEvent{
+		Files:       t.filesFound.Load(),
+		Dirs:        t.dirsFound.Load(),
+		CurrentPath: currentPath,
+	})
+}
+
+// loadDirectory loads the immediate children of a directory, using cache if available.
+// recursive additionally pre-loads every descendant subtree before returning.
+func (t *Tree) loadDirectory(ctx context.Context, node *Node, recursive bool) []*Node {
 	if !node.IsDir {
 		return nil
 	}
 
-	// Check cache first
-	if item := t.cache.Get(node.Path); item != nil {
-		log.Printf("Cache hit for: %s", node.Path)
-		return item.Value()
+	select {
+	case <-ctx.Done():
+		return nil
+	default:
 	}
 
 	log.Printf("Loading directory: %s (recursive=%v)", node.Path, recursive)
+	node.setState(StateLoading)
+
+	// Stat the directory first so a cache hit can be validated against its current
+	// mtime+size instead of trusting a possibly-stale entry.
+	dirInfo, statErr := os.Stat(node.Path)
+	if statErr == nil {
+		if entry, ok := t.cache.Get(node.Path); ok &&
+			entry.ModTime.Equal(dirInfo.ModTime()) && entry.Size == dirInfo.Size() {
+			log.Printf("Cache hit for: %s", node.Path)
+			children := t.fromChildRecords(node, entry.Children)
+
+			// A cache hit skips fastwalk entirely, so the counters and progress
+			// callback it would otherwise have driven have to be fed here instead - a
+			// warm-cache relaunch should report the same totals as a cold walk.
+			for _, child := range children {
+				if child.IsDir {
+					t.dirsFound.Add(1)
+				} else {
+					t.filesFound.Add(1)
+				}
+				t.publishProgress(child.Path, false)
+			}
+
+			t.resolveMetadata(children)
+			if recursive {
+				for _, child := range children {
+					if child.IsDir {
+						subChildren := t.loadDirectory(ctx, child, true)
+						child.mu.Lock()
+						child.Children = subChildren
+						child.mu.Unlock()
+						child.setState(StateLoaded)
+					}
+				}
+			}
+			node.setState(StateLoaded)
+			return children
+		}
+	}
 
 	children := make([]*Node, 0)
 	posterMap := make(map[string]string) // basename -> poster path
 	var childrenMu sync.Mutex
 
-	// Use fastwalk for faster directory scanning (non-recursive)
 	conf := fastwalk.Config{
 		Follow:     false,
-		NumWorkers: 1,                      // Single directory, no need for parallelism
+		NumWorkers: t.numWorkers,
 		MaxDepth:   1,                      // Only immediate children
 		Sort:       fastwalk.SortDirsFirst, // Sort entries for consistent order
 	}
@@ -219,8 +432,8 @@ func (t *Tree) loadDirectory(node *Node, recursive bool) []*Node {
 		}
 
 		// Collect poster files
-		if IsPosterFile(name) {
-			base := strings.TrimSuffix(strings.TrimSuffix(name, "-poster.jpg"), "-poster.jpeg")
+		if t.filter.Policy.IsPosterFile(name) {
+			base := t.filter.Policy.TrimPosterSuffix(name)
 			childrenMu.Lock()
 			posterMap[base] = path
 			childrenMu.Unlock()
@@ -230,13 +443,32 @@ func (t *Tree) loadDirectory(node *Node, recursive bool) []*Node {
 			return nil
 		}
 
+		// Bare folder-level poster names (poster.jpg, folder.jpg, fanart.jpg, ...) are
+		// this directory's own poster - resolved for its parent's listing by
+		// folderPosterProvider (see metadata.go), not by posterMap. Exclude them here
+		// the same way IsPosterFile is excluded above, so they don't show up as their
+		// own browsable child.
+		if t.filter.Policy.IsBarePosterName(name) {
+			if de.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		kind := t.filter.Policy.Kind(name, de.IsDir())
+		if !t.filter.Mode.Allows(kind) {
+			return nil
+		}
+
 		child := &Node{
 			Name:     name,
 			Path:     path,
 			IsDir:    de.IsDir(),
 			Children: make([]*Node, 0),
 			Parent:   node,
-			IsVideo:  !de.IsDir() && IsVideoFile(name),
+			IsVideo:  !de.IsDir() && t.filter.Policy.IsVideo(name),
+			Kind:     kind,
+			tokens:   tokenize(name),
 		}
 
 		childrenMu.Lock()
@@ -255,6 +487,8 @@ func (t *Tree) loadDirectory(node *Node, recursive bool) []*Node {
 			t.filesFound.Add(1)
 		}
 
+		t.publishProgress(path, false)
+
 		// Don't recurse into subdirectories
 		if de.IsDir() {
 			return filepath.SkipDir
@@ -265,25 +499,41 @@ func (t *Tree) loadDirectory(node *Node, recursive bool) []*Node {
 
 	if err != nil {
 		log.Printf("Error walking directory %s: %v", node.Path, err)
+		node.setState(StateError)
 	}
 
 	// Associate posters with children (no additional ReadDir needed)
 	t.associatePosters(node.Path, children, posterMap)
 
+	// Consult registered metadata providers (NFO, JSON sidecars, folder posters, ...)
+	t.resolveMetadata(children)
+
 	// Recursively load subdirectories if requested
 	if recursive {
 		for _, child := range children {
 			if child.IsDir {
-				subChildren := t.loadDirectory(child, true)
+				subChildren := t.loadDirectory(ctx, child, true)
 				child.mu.Lock()
 				child.Children = subChildren
 				child.mu.Unlock()
+				child.setState(StateLoaded)
 			}
 		}
 	}
 
-	// Store in cache
-	t.cache.Set(node.Path, children, ttlcache.DefaultTTL)
+	// Store in cache, keyed against the directory's mtime+size so a later call can
+	// tell whether the cached listing is still valid without re-walking.
+	if dirInfo, err := os.Stat(node.Path); err == nil {
+		t.cache.Set(node.Path, dirCacheEntry{
+			ModTime:  dirInfo.ModTime(),
+			Size:     dirInfo.Size(),
+			Children: toChildRecords(children),
+		})
+	}
+
+	if node.State() != StateError {
+		node.setState(StateLoaded)
+	}
 
 	return children
 }
@@ -308,12 +558,116 @@ func (t *Tree) associatePosters(dirPath string, children []*Node, posterMap map[
 	}
 }
 
-// UpdateVisibleNodes updates the list of nodes visible in the current directory
+// rebuildPosterMap re-scans dirPath's current on-disk entries for sibling "-poster"
+// style poster files (see MediaPolicy.IsPosterFile), the same way loadDirectory's
+// fastwalk collects posterMap during a cold walk. Watcher uses this to re-run
+// associatePosters for a directory after a live create/modify event, without doing a
+// full reload. Bare folder-level poster names are resolved separately via
+// folderPosterProvider and are not included here.
+func (t *Tree) rebuildPosterMap(dirPath string) map[string]string {
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		return nil
+	}
+
+	posterMap := make(map[string]string)
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		name := e.Name()
+		if t.filter.Policy.IsPosterFile(name) {
+			posterMap[t.filter.Policy.TrimPosterSuffix(name)] = filepath.Join(dirPath, name)
+		}
+	}
+	return posterMap
+}
+
+// resolveMetadata runs the registered MetadataProviders, in priority order, against
+// every child, stopping at the first provider that returns a result for that child.
+// A child's PosterPath is backfilled from the resolved metadata's ThumbPath when the
+// "-poster" filename convention didn't already supply one.
+func (t *Tree) resolveMetadata(children []*Node) {
+	t.mu.RLock()
+	providers := t.providers
+	t.mu.RUnlock()
+
+	for _, child := range children {
+		for _, p := range providers {
+			md := p.Lookup(child)
+			if md == nil {
+				continue
+			}
+			child.Metadata = md
+			if child.PosterPath == "" && md.ThumbPath != "" {
+				child.PosterPath = md.ThumbPath
+			}
+			break
+		}
+	}
+}
+
+// Reload invalidates the cached children for the directory at path (and, if
+// recursive is set, every cached descendant) and re-walks it, reusing the rest
+// of the tree's cache untouched.
+func (t *Tree) Reload(path string, recursive bool) error {
+	t.mu.RLock()
+	node, ok := t.nodeMap[path]
+	t.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("tree: unknown path %q", path)
+	}
+	if !node.IsDir {
+		return fmt.Errorf("tree: %q is not a directory", path)
+	}
+
+	t.invalidateSubtree(node, recursive)
+
+	children := t.loadDirectory(t.ctx, node, recursive)
+	node.mu.Lock()
+	node.Children = children
+	node.mu.Unlock()
+
+	if node == t.CurrentDir {
+		t.UpdateVisibleNodes()
+	}
+	return nil
+}
+
+// invalidateSubtree drops path from the cache, and every descendant's cache
+// entry too when recursive is set.
+func (t *Tree) invalidateSubtree(node *Node, recursive bool) {
+	t.cache.Delete(node.Path)
+	if !recursive {
+		return
+	}
+
+	node.mu.RLock()
+	children := node.Children
+	node.mu.RUnlock()
+
+	for _, c := range children {
+		if c.IsDir {
+			t.invalidateSubtree(c, true)
+		}
+	}
+}
+
+// UpdateVisibleNodes updates the list of nodes visible in the current directory.
+// In ViewFlatVideos mode it instead collects every video below CurrentDir.
 func (t *Tree) UpdateVisibleNodes() {
-	t.CurrentDir.mu.RLock()
-	t.VisibleNodes = make([]*Node, len(t.CurrentDir.Children))
-	copy(t.VisibleNodes, t.CurrentDir.Children)
-	t.CurrentDir.mu.RUnlock()
+	t.mu.RLock()
+	mode := t.viewMode
+	t.mu.RUnlock()
+
+	if mode == ViewFlatVideos {
+		t.VisibleNodes = collectVideos(t.CurrentDir)
+	} else {
+		t.CurrentDir.mu.RLock()
+		t.VisibleNodes = make([]*Node, len(t.CurrentDir.Children))
+		copy(t.VisibleNodes, t.CurrentDir.Children)
+		t.CurrentDir.mu.RUnlock()
+	}
 
 	// Reset selection if out of bounds
 	if t.SelectedIdx >= len(t.VisibleNodes) {
@@ -324,6 +678,24 @@ func (t *Tree) UpdateVisibleNodes() {
 	}
 }
 
+// collectVideos recursively gathers every video Node below node, in tree order.
+func collectVideos(node *Node) []*Node {
+	node.mu.RLock()
+	children := make([]*Node, len(node.Children))
+	copy(children, node.Children)
+	node.mu.RUnlock()
+
+	videos := make([]*Node, 0, len(children))
+	for _, c := range children {
+		if c.IsDir {
+			videos = append(videos, collectVideos(c)...)
+		} else if c.IsVideo {
+			videos = append(videos, c)
+		}
+	}
+	return videos
+}
+
 // NavigateUp moves selection up (by columns)
 func (t *Tree) NavigateUp(cols int) {
 	if t.SelectedIdx >= cols {
@@ -369,7 +741,7 @@ func (t *Tree) Enter() {
 
 	// Tree is pre-loaded recursively, but check cache just in case
 	// This ensures cache expiry is properly handled and provides fallback
-	children := t.loadDirectory(selected, false) // non-recursive for on-demand loading
+	children := t.loadDirectory(t.ctx, selected, false) // non-recursive for on-demand loading
 	selected.mu.Lock()
 	selected.Children = children
 	selected.mu.Unlock()
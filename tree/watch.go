@@ -0,0 +1,290 @@
+package tree
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce is how long a burst of events for the same path is coalesced
+// before StartWatch applies a single update for it.
+const watchDebounce = 250 * time.Millisecond
+
+// ChangeKind describes the kind of change an OnChange callback is notified about.
+type ChangeKind int
+
+const (
+	ChangeCreated ChangeKind = iota
+	ChangeRemoved
+	ChangeModified
+)
+
+// Watcher applies incremental filesystem changes to a Tree after StartWatch.
+type Watcher struct {
+	tree     *Tree
+	fsw      *fsnotify.Watcher
+	ctx      context.Context
+	onChange func(node *Node, kind ChangeKind)
+
+	mu      sync.Mutex
+	pending map[string]*time.Timer
+}
+
+// StartWatch registers recursive watches on every directory currently loaded in the
+// tree and applies incremental updates (inserting/removing *Nodes, re-running
+// associatePosters and resolveMetadata for the affected directory, updating nodeMap)
+// as filesystem events arrive, coalesced over watchDebounce. New subdirectories are
+// auto-watched as they're discovered. onChange, if non-nil, fires after each applied
+// change so UI code can refresh VisibleNodes without a full reload. Cancel ctx, or
+// call the returned Watcher's Close, to stop watching.
+func (t *Tree) StartWatch(ctx context.Context, onChange func(node *Node, kind ChangeKind)) (*Watcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Watcher{
+		tree:     t,
+		fsw:      fsw,
+		ctx:      ctx,
+		onChange: onChange,
+		pending:  make(map[string]*time.Timer),
+	}
+
+	t.mu.RLock()
+	for path, node := range t.nodeMap {
+		if node.IsDir {
+			if err := fsw.Add(path); err != nil {
+				log.Printf("watch: failed to add %s: %v", path, err)
+			}
+		}
+	}
+	t.mu.RUnlock()
+
+	go w.run(ctx)
+	return w, nil
+}
+
+// Close stops the watcher and releases its underlying OS resources.
+func (w *Watcher) Close() error {
+	return w.fsw.Close()
+}
+
+func (w *Watcher) run(ctx context.Context) {
+	defer w.fsw.Close()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-w.fsw.Events:
+			if !ok {
+				return
+			}
+			w.schedule(event)
+		case err, ok := <-w.fsw.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("watch: error: %v", err)
+		}
+	}
+}
+
+// schedule debounces events for the same path so a burst of writes (e.g. a poster
+// being copied in alongside its video) results in one applied update.
+func (w *Watcher) schedule(event fsnotify.Event) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if timer, ok := w.pending[event.Name]; ok {
+		timer.Stop()
+	}
+	w.pending[event.Name] = time.AfterFunc(watchDebounce, func() {
+		w.mu.Lock()
+		delete(w.pending, event.Name)
+		w.mu.Unlock()
+		w.apply(event)
+	})
+}
+
+// apply reconciles the tree with the current on-disk state of event.Name. Renames
+// show up as a removal of the old path and a creation of the new one, which this
+// handles the same as any other create/remove.
+func (w *Watcher) apply(event fsnotify.Event) {
+	t := w.tree
+	dirPath := filepath.Dir(event.Name)
+
+	t.mu.RLock()
+	parent, ok := t.nodeMap[dirPath]
+	t.mu.RUnlock()
+	if !ok {
+		return // parent directory isn't loaded, nothing to reconcile
+	}
+
+	info, statErr := os.Stat(event.Name)
+
+	parent.mu.Lock()
+	existingIdx := -1
+	for i, c := range parent.Children {
+		if c.Path == event.Name {
+			existingIdx = i
+			break
+		}
+	}
+
+	switch {
+	case statErr != nil: // removed (or renamed away)
+		if existingIdx == -1 {
+			parent.mu.Unlock()
+			return
+		}
+		node := parent.Children[existingIdx]
+		parent.Children = append(parent.Children[:existingIdx:existingIdx], parent.Children[existingIdx+1:]...)
+		parent.mu.Unlock()
+
+		w.removeSubtree(node)
+		t.cache.Delete(dirPath)
+
+		if parent == t.CurrentDir {
+			t.UpdateVisibleNodes()
+		}
+		w.notify(node, ChangeRemoved)
+
+	case existingIdx == -1: // created
+		name := info.Name()
+
+		if !info.IsDir() && (t.filter.Policy.IsPosterFile(name) || t.filter.Policy.IsBarePosterName(name)) {
+			// A poster file appeared rather than a browsable item: don't list it
+			// as its own node (loadDirectory never does either), just re-run
+			// associatePosters so it gets picked up by its matching sibling, or, for a
+			// bare folder-level name, re-resolve parent so it becomes parent's poster.
+			children := parent.Children
+			parent.mu.Unlock()
+
+			t.cache.Delete(dirPath)
+			t.associatePosters(dirPath, children, t.rebuildPosterMap(dirPath))
+			t.resolveMetadata(children)
+			if t.filter.Policy.IsBarePosterName(name) {
+				t.resolveMetadata([]*Node{parent})
+			}
+
+			if parent == t.CurrentDir {
+				t.UpdateVisibleNodes()
+			}
+			w.notify(parent, ChangeModified)
+			return
+		}
+
+		node := &Node{
+			Name:     name,
+			Path:     event.Name,
+			IsDir:    info.IsDir(),
+			Children: make([]*Node, 0),
+			Parent:   parent,
+			IsVideo:  !info.IsDir() && t.filter.Policy.IsVideo(name),
+			Kind:     t.filter.Policy.Kind(name, info.IsDir()),
+			tokens:   tokenize(name),
+		}
+		parent.Children = append(parent.Children, node)
+		children := parent.Children
+		parent.mu.Unlock()
+
+		t.mu.Lock()
+		t.nodeMap[node.Path] = node
+		t.mu.Unlock()
+
+		if node.IsDir {
+			// The new path may be an existing, already-populated directory (e.g. a
+			// subtree moved in from elsewhere), not an empty one: walk and load it
+			// the same way loadDirectory would for a fresh Tree, so its children
+			// show up immediately instead of appearing permanently empty.
+			subChildren := t.loadDirectory(w.ctx, node, true)
+			node.mu.Lock()
+			node.Children = subChildren
+			node.mu.Unlock()
+			node.setState(StateLoaded)
+			w.addWatches(node)
+		}
+
+		t.cache.Delete(dirPath)
+		t.associatePosters(dirPath, children, t.rebuildPosterMap(dirPath))
+		t.resolveMetadata(children)
+
+		if parent == t.CurrentDir {
+			t.UpdateVisibleNodes()
+		}
+		w.notify(node, ChangeCreated)
+
+	default: // modified in place (e.g. a poster was overwritten)
+		node := parent.Children[existingIdx]
+		children := parent.Children
+		parent.mu.Unlock()
+
+		t.cache.Delete(dirPath)
+		t.associatePosters(dirPath, children, t.rebuildPosterMap(dirPath))
+		t.resolveMetadata(children)
+
+		if parent == t.CurrentDir {
+			t.UpdateVisibleNodes()
+		}
+		w.notify(node, ChangeModified)
+	}
+}
+
+func (w *Watcher) notify(node *Node, kind ChangeKind) {
+	if w.onChange != nil {
+		w.onChange(node, kind)
+	}
+}
+
+// addWatches registers node and every directory beneath it (already loaded into
+// node.Children by the caller) with fsw, so newly-created or newly-moved-in
+// subdirectories get their own future events watched too.
+func (w *Watcher) addWatches(node *Node) {
+	if !node.IsDir {
+		return
+	}
+	if err := w.fsw.Add(node.Path); err != nil {
+		log.Printf("watch: failed to add %s: %v", node.Path, err)
+	}
+
+	node.mu.RLock()
+	children := make([]*Node, len(node.Children))
+	copy(children, node.Children)
+	node.mu.RUnlock()
+
+	for _, child := range children {
+		w.addWatches(child)
+	}
+}
+
+// removeSubtree deletes node and every loaded descendant from t.nodeMap, and
+// removes fsw's watch on any of them that was a directory, so a removed (or
+// renamed-away) folder doesn't leak its whole subtree forever.
+func (w *Watcher) removeSubtree(node *Node) {
+	t := w.tree
+
+	node.mu.RLock()
+	children := make([]*Node, len(node.Children))
+	copy(children, node.Children)
+	node.mu.RUnlock()
+
+	for _, child := range children {
+		w.removeSubtree(child)
+	}
+
+	t.mu.Lock()
+	delete(t.nodeMap, node.Path)
+	t.mu.Unlock()
+
+	if node.IsDir {
+		if err := w.fsw.Remove(node.Path); err != nil {
+			log.Printf("watch: failed to remove watch on %s: %v", node.Path, err)
+		}
+	}
+}
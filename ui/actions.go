@@ -0,0 +1,157 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+)
+
+// Action is one keyboard-triggerable operation against a MediaGrid. Exactly one of
+// Key (for a bare, unmodified physical key), Rune (for a printable character key) or
+// Key+Mod (for a modified shortcut, e.g. Ctrl+F) should be set; bindTables uses
+// whichever is set to decide how the action is wired up.
+type Action struct {
+	Name string // stable id; used as the fyne.Preferences key when rebound
+	Desc string // shown in the help overlay and the rebind dialog
+
+	Key  fyne.KeyName
+	Rune rune
+	Mod  desktop.Modifier
+
+	Do func(g *MediaGrid)
+}
+
+// prefsKey returns the fyne.Preferences key a's binding is persisted under.
+func (a *Action) prefsKey() string { return "shortcut." + a.Name }
+
+// binding encodes a's current Key/Rune/Mod as a string, for display and persistence.
+func (a *Action) binding() string {
+	switch {
+	case a.Rune != 0:
+		return string(a.Rune)
+	case a.Mod != 0:
+		return modifierName(a.Mod) + "+" + string(a.Key)
+	default:
+		return string(a.Key)
+	}
+}
+
+// applyBinding parses a string produced by binding (or typed by a user in the
+// preferences dialog) back into a's Key/Rune/Mod.
+func (a *Action) applyBinding(s string) error {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return fmt.Errorf("ui: empty shortcut binding for %s", a.Name)
+	}
+
+	if isSingleRune(s) {
+		a.Rune, a.Key, a.Mod = []rune(s)[0], "", 0
+		return nil
+	}
+
+	parts := strings.Split(s, "+")
+	key := fyne.KeyName(parts[len(parts)-1])
+	var mod desktop.Modifier
+	for _, p := range parts[:len(parts)-1] {
+		m, ok := parseModifier(p)
+		if !ok {
+			return fmt.Errorf("ui: unknown modifier %q in binding %q", p, s)
+		}
+		mod |= m
+	}
+
+	a.Rune, a.Key, a.Mod = 0, key, mod
+	return nil
+}
+
+// isSingleRune reports whether s is exactly one printable rune, i.e. a Rune
+// binding rather than a Key/Mod one.
+func isSingleRune(s string) bool {
+	r := []rune(s)
+	return len(r) == 1 && r[0] >= 0x20
+}
+
+func modifierName(m desktop.Modifier) string {
+	var parts []string
+	if m&desktop.ControlModifier != 0 {
+		parts = append(parts, "Control")
+	}
+	if m&desktop.ShiftModifier != 0 {
+		parts = append(parts, "Shift")
+	}
+	if m&desktop.AltModifier != 0 {
+		parts = append(parts, "Alt")
+	}
+	if m&desktop.SuperModifier != 0 {
+		parts = append(parts, "Super")
+	}
+	return strings.Join(parts, "+")
+}
+
+func parseModifier(s string) (desktop.Modifier, bool) {
+	switch s {
+	case "Control":
+		return desktop.ControlModifier, true
+	case "Shift":
+		return desktop.ShiftModifier, true
+	case "Alt":
+		return desktop.AltModifier, true
+	case "Super":
+		return desktop.SuperModifier, true
+	}
+	return 0, false
+}
+
+// defaultActions returns the grid's built-in bindings in display order. Callers that
+// want to override one in place should mutate the returned slice before passing it to
+// bindTables, rather than filtering it down, so the help overlay stays complete.
+func defaultActions() []*Action {
+	return []*Action{
+		{Name: "nav-up", Desc: "Move selection up a row", Key: fyne.KeyUp, Do: func(g *MediaGrid) { g.moveSelection(func() { g.tree.NavigateUp(g.cols) }) }},
+		{Name: "nav-down", Desc: "Move selection down a row", Key: fyne.KeyDown, Do: func(g *MediaGrid) { g.moveSelection(func() { g.tree.NavigateDown(g.cols) }) }},
+		{Name: "nav-left", Desc: "Move selection left", Key: fyne.KeyLeft, Do: func(g *MediaGrid) { g.moveSelection(g.tree.NavigateLeft) }},
+		{Name: "nav-right", Desc: "Move selection right", Key: fyne.KeyRight, Do: func(g *MediaGrid) { g.moveSelection(g.tree.NavigateRight) }},
+		{Name: "open", Desc: "Open the selected item", Key: fyne.KeyReturn, Do: (*MediaGrid).doOpenSelected},
+		{Name: "go-up", Desc: "Go up to the parent directory", Key: fyne.KeyBackspace, Do: (*MediaGrid).doGoUp},
+		{Name: "home", Desc: "Jump to the first item", Key: fyne.KeyHome, Do: func(g *MediaGrid) { g.moveSelection(g.selectFirst) }},
+		{Name: "end", Desc: "Jump to the last item", Key: fyne.KeyEnd, Do: func(g *MediaGrid) { g.moveSelection(g.selectLast) }},
+		{Name: "page-up", Desc: "Page up by one screenful of rows", Key: fyne.KeyPageUp, Do: func(g *MediaGrid) { g.moveSelection(g.pageUp) }},
+		{Name: "page-down", Desc: "Page down by one screenful of rows", Key: fyne.KeyPageDown, Do: func(g *MediaGrid) { g.moveSelection(g.pageDown) }},
+		{Name: "escape", Desc: "Close the search/filter popups or the details panel", Key: fyne.KeyEscape, Do: (*MediaGrid).doEscape},
+		{Name: "search", Desc: "Focus the search box", Rune: '/', Do: (*MediaGrid).doFocusSearch},
+		{Name: "help", Desc: "Show this list of shortcuts", Rune: '?', Do: (*MediaGrid).doShowHelp},
+		{Name: "toggle-filter", Desc: "Toggle the filter sidebar", Key: fyne.KeyF, Mod: desktop.ControlModifier, Do: (*MediaGrid).doToggleFilterSidebar},
+		{Name: "preferences", Desc: "Rebind keyboard shortcuts", Key: fyne.KeyComma, Mod: desktop.ControlModifier, Do: (*MediaGrid).doShowPreferences},
+	}
+}
+
+// findAction returns the action named name, if any.
+func findAction(actions []*Action, name string) (*Action, bool) {
+	for _, a := range actions {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return nil, false
+}
+
+// loadBindings overwrites each action's Key/Rune/Mod with whatever was last persisted
+// to fyne.Preferences for it, leaving the default binding untouched where the user
+// never rebound it.
+func loadBindings(actions []*Action) {
+	prefs := fyne.CurrentApp().Preferences()
+	for _, a := range actions {
+		if s := prefs.String(a.prefsKey()); s != "" {
+			if err := a.applyBinding(s); err != nil {
+				fyne.LogError("ui: ignoring invalid saved shortcut", err)
+			}
+		}
+	}
+}
+
+// saveBinding persists a's current binding so it survives restarts.
+func saveBinding(a *Action) {
+	fyne.CurrentApp().Preferences().SetString(a.prefsKey(), a.binding())
+}
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+)
+
+func TestActionBindingRoundTrip(t *testing.T) {
+	cases := []*Action{
+		{Name: "search", Rune: '/'},
+		{Name: "go-up", Key: fyne.KeyBackspace},
+		{Name: "toggle-filter", Key: fyne.KeyF, Mod: desktop.ControlModifier},
+		{Name: "preferences", Key: fyne.KeyComma, Mod: desktop.ControlModifier | desktop.ShiftModifier},
+	}
+
+	for _, want := range cases {
+		s := want.binding()
+
+		got := &Action{Name: want.Name}
+		if err := got.applyBinding(s); err != nil {
+			t.Fatalf("applyBinding(%q) for %s: %v", s, want.Name, err)
+		}
+		if got.Key != want.Key || got.Rune != want.Rune || got.Mod != want.Mod {
+			t.Errorf("round trip of %q = %+v, want Key=%v Rune=%v Mod=%v", s, got, want.Key, want.Rune, want.Mod)
+		}
+	}
+}
+
+func TestApplyBindingRejectsInvalidInput(t *testing.T) {
+	cases := []string{"", "   ", "Control+Oops+X"}
+
+	for _, s := range cases {
+		a := &Action{Name: "test"}
+		if err := a.applyBinding(s); err == nil {
+			t.Errorf("applyBinding(%q) = nil error, want error", s)
+		}
+	}
+}
+
+func TestParseModifier(t *testing.T) {
+	cases := []struct {
+		in   string
+		want desktop.Modifier
+		ok   bool
+	}{
+		{"Control", desktop.ControlModifier, true},
+		{"Shift", desktop.ShiftModifier, true},
+		{"Alt", desktop.AltModifier, true},
+		{"Super", desktop.SuperModifier, true},
+		{"Nope", 0, false},
+	}
+
+	for _, c := range cases {
+		got, ok := parseModifier(c.in)
+		if ok != c.ok || got != c.want {
+			t.Errorf("parseModifier(%q) = (%v, %v), want (%v, %v)", c.in, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestIsSingleRune(t *testing.T) {
+	cases := []struct {
+		in   string
+		want bool
+	}{
+		{"/", true},
+		{"?", true},
+		{"Control+F", false},
+		{"", false},
+		{"ab", false},
+	}
+
+	for _, c := range cases {
+		if got := isSingleRune(c.in); got != c.want {
+			t.Errorf("isSingleRune(%q) = %v, want %v", c.in, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,263 @@
+// Package imagecache provides a shared, byte-budgeted LRU cache of decoded and
+// downscaled poster images, backed by a fixed-size pool of decode workers so a large
+// library doesn't spawn one goroutine per poster and thrash disk.
+package imagecache
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	"image/png"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"golang.org/x/image/draw"
+)
+
+// DefaultByteBudget is used when New is given a non-positive budget.
+const DefaultByteBudget int64 = 128 << 20 // 128 MiB
+
+// Cache decodes and downscales poster images on a fixed worker pool, and keeps the
+// results in an LRU bounded by total encoded bytes.
+type Cache struct {
+	byteBudget int64
+
+	mu    sync.Mutex
+	lru   *list.List // of *entry; front = most recently used
+	index map[string]*list.Element
+	size  int64
+
+	jobsMu   sync.Mutex
+	inflight map[string][]chan result
+
+	jobs chan job
+}
+
+type entry struct {
+	key      string
+	resource fyne.Resource
+	bytes    int64
+}
+
+type job struct {
+	path       string
+	targetSize fyne.Size
+	key        string
+}
+
+type result struct {
+	resource fyne.Resource
+	err      error
+}
+
+// New returns a Cache with the given byte budget (<=0 uses DefaultByteBudget),
+// backed by runtime.NumCPU() decode workers.
+func New(byteBudget int64) *Cache {
+	if byteBudget <= 0 {
+		byteBudget = DefaultByteBudget
+	}
+
+	c := &Cache{
+		byteBudget: byteBudget,
+		lru:        list.New(),
+		index:      make(map[string]*list.Element),
+		inflight:   make(map[string][]chan result),
+		jobs:       make(chan job, 64),
+	}
+
+	workers := runtime.NumCPU()
+	if workers < 1 {
+		workers = 1
+	}
+	for i := 0; i < workers; i++ {
+		go c.worker()
+	}
+
+	return c
+}
+
+func cacheKey(path string, size fyne.Size) string {
+	return fmt.Sprintf("%s@%dx%d", path, int(size.Width), int(size.Height))
+}
+
+// Get returns path decoded and downscaled to targetSize, from cache if present, or
+// via the decode worker pool otherwise. Concurrent Get calls for the same path and
+// targetSize share a single decode. Cancelling ctx drops this caller's wait (and, if
+// no other caller is waiting on the same decode, the still-queued job itself) without
+// blocking on the worker pool.
+func (c *Cache) Get(ctx context.Context, path string, targetSize fyne.Size) (fyne.Resource, error) {
+	key := cacheKey(path, targetSize)
+
+	c.mu.Lock()
+	if el, ok := c.index[key]; ok {
+		c.lru.MoveToFront(el)
+		res := el.Value.(*entry).resource
+		c.mu.Unlock()
+		return res, nil
+	}
+	c.mu.Unlock()
+
+	ch := make(chan result, 1)
+	c.jobsMu.Lock()
+	waiters, inFlight := c.inflight[key]
+	c.inflight[key] = append(waiters, ch)
+	c.jobsMu.Unlock()
+
+	if !inFlight {
+		select {
+		case c.jobs <- job{path: path, targetSize: targetSize, key: key}:
+		case <-ctx.Done():
+			c.dropWaiter(key, ch)
+			return nil, ctx.Err()
+		}
+	}
+
+	select {
+	case r := <-ch:
+		return r.resource, r.err
+	case <-ctx.Done():
+		c.dropWaiter(key, ch)
+		return nil, ctx.Err()
+	}
+}
+
+func (c *Cache) worker() {
+	for j := range c.jobs {
+		// A job is shared by every caller coalesced onto the same key, so it can't
+		// be tied to any one of their contexts - that would let one caller's
+		// cancellation poison the result for the others. Instead, skip the decode
+		// entirely once every waiter has dropped out (see dropWaiter).
+		if !c.hasWaiters(j.key) {
+			continue
+		}
+
+		res, err := c.decode(j.path, j.targetSize)
+		if err == nil {
+			c.store(j.key, res)
+		}
+		c.deliver(j.key, result{resource: res, err: err})
+	}
+}
+
+// hasWaiters reports whether key still has at least one caller waiting on it.
+func (c *Cache) hasWaiters(key string) bool {
+	c.jobsMu.Lock()
+	defer c.jobsMu.Unlock()
+	return len(c.inflight[key]) > 0
+}
+
+// decode reads, decodes, and downscales path to targetSize via CatmullRom
+// resampling, so a 2000x3000 poster doesn't consume 24MB once cached.
+func (c *Cache) decode(path string, targetSize fyne.Size) (fyne.Resource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	w, h := int(targetSize.Width), int(targetSize.Height)
+	if w <= 0 || h <= 0 {
+		return fyne.NewStaticResource(path, data), nil
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, src.Bounds(), draw.Over, nil)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, dst); err != nil {
+		return nil, err
+	}
+
+	return fyne.NewStaticResource(path, buf.Bytes()), nil
+}
+
+func (c *Cache) store(key string, res fyne.Resource) {
+	size := int64(len(res.Content()))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.index[key]; ok {
+		old := el.Value.(*entry)
+		c.size += size - old.bytes
+		old.resource, old.bytes = res, size
+		c.lru.MoveToFront(el)
+	} else {
+		el := c.lru.PushFront(&entry{key: key, resource: res, bytes: size})
+		c.index[key] = el
+		c.size += size
+	}
+
+	for c.size > c.byteBudget {
+		back := c.lru.Back()
+		if back == nil {
+			break
+		}
+		c.evictLocked(back)
+	}
+}
+
+// evictLocked removes el from the LRU. Callers must hold c.mu.
+func (c *Cache) evictLocked(el *list.Element) {
+	e := el.Value.(*entry)
+	c.size -= e.bytes
+	delete(c.index, e.key)
+	c.lru.Remove(el)
+}
+
+// EvictPrefix removes every cached entry whose source path starts with prefix,
+// e.g. a library root being closed. Cache keys are "path@WxH" (see cacheKey), so
+// this matches everything up to the "@" against prefix.
+func (c *Cache) EvictPrefix(prefix string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, el := range c.index {
+		if path, _, ok := strings.Cut(key, "@"); ok && strings.HasPrefix(path, prefix) {
+			c.evictLocked(el)
+		}
+	}
+}
+
+func (c *Cache) deliver(key string, r result) {
+	c.jobsMu.Lock()
+	waiters := c.inflight[key]
+	delete(c.inflight, key)
+	c.jobsMu.Unlock()
+
+	for _, ch := range waiters {
+		ch <- r
+	}
+}
+
+// dropWaiter removes ch from key's waiter list without delivering to it, used when a
+// caller's ctx is cancelled before its decode completes. Once no waiter is left, key is
+// forgotten so the next Get for it submits a fresh job instead of waiting on one the
+// workers will skip.
+func (c *Cache) dropWaiter(key string, ch chan result) {
+	c.jobsMu.Lock()
+	defer c.jobsMu.Unlock()
+
+	waiters := c.inflight[key]
+	for i, w := range waiters {
+		if w == ch {
+			waiters = append(waiters[:i:i], waiters[i+1:]...)
+			break
+		}
+	}
+	if len(waiters) == 0 {
+		delete(c.inflight, key)
+	} else {
+		c.inflight[key] = waiters
+	}
+}
@@ -0,0 +1,66 @@
+package imagecache
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+func writeTestPNG(t *testing.T, dir string) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 4))); err != nil {
+		t.Fatalf("png.Encode: %v", err)
+	}
+
+	path := filepath.Join(dir, "poster.png")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return path
+}
+
+// TestGetCoalescedWaiterSurvivesOthersCancellation exercises a job after one of its
+// two coalesced waiters has already dropped out (as Get's ctx.Done() path does): the
+// still-live waiter must get the decoded image, not the dropped waiter's cancellation.
+func TestGetCoalescedWaiterSurvivesOthersCancellation(t *testing.T) {
+	c := New(DefaultByteBudget)
+	path := writeTestPNG(t, t.TempDir())
+	size := fyne.NewSize(16, 16)
+	key := cacheKey(path, size)
+
+	dropped := make(chan result, 1)
+	live := make(chan result, 1)
+
+	c.jobsMu.Lock()
+	c.inflight[key] = []chan result{dropped, live}
+	c.jobsMu.Unlock()
+	c.dropWaiter(key, dropped)
+
+	c.jobs <- job{path: path, targetSize: size, key: key}
+
+	select {
+	case r := <-live:
+		if r.err != nil {
+			t.Fatalf("live waiter got an error: %v", r.err)
+		}
+		if r.resource == nil {
+			t.Fatal("live waiter got a nil resource")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("live waiter never received a result")
+	}
+
+	select {
+	case r := <-dropped:
+		t.Fatalf("dropped waiter unexpectedly received a result: %+v", r)
+	default:
+	}
+}
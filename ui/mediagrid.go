@@ -1,29 +1,36 @@
 package ui
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"image"
 	"image/color"
-	_ "image/jpeg"
-	_ "image/png"
+	"io"
 	"log"
+	"math"
 	"os"
+	"os/exec"
+	"runtime"
+	"strings"
 	"sync"
-	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/data/binding"
-	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 
+	"github.com/bruno-domazet/go-media-posters/poster"
 	"github.com/bruno-domazet/go-media-posters/tree"
+	"github.com/bruno-domazet/go-media-posters/ui/imagecache"
 )
 
+// overscanRows is how many extra rows of cards to keep bound above and below the
+// visible viewport, so a fast scroll doesn't flash unbound cards before they catch up.
+const overscanRows = 2
+
 // MediaItem represents a single item in the grid with data binding
 type MediaItem struct {
 	Node         *tree.Node
@@ -31,66 +38,142 @@ type MediaItem struct {
 	Index        int
 }
 
-// MediaGrid displays media as a grid of poster images
+// poolSlot is one reusable card widget and the item id (index into g.items) it's
+// currently bound to, or -1 if unbound.
+type poolSlot struct {
+	obj fyne.CanvasObject
+	id  int
+}
+
+// MediaGrid displays media as a virtualized grid of poster images. Only the cards
+// needed to cover the current viewport (plus a small overscan) are ever allocated;
+// scrolling rebinds the same pooled widgets to different items instead of creating
+// and tearing down new ones, so libraries with tens of thousands of entries open
+// and scroll at a constant cost.
 type MediaGrid struct {
 	widget.BaseWidget
-	tree         *tree.Tree
-	window       fyne.Window
-	cols         int
-	rowHeight    float32
-	colWidth     float32
-	items        []*MediaItem
-	visibleStart int
-	visibleEnd   int
-	batchSize    int
-	container    *fyne.Container
+	tree      *tree.Tree
+	window    fyne.Window
+	cols      int
+	rowHeight float32
+	colWidth  float32
+	items     []*MediaItem
+
+	// Length, CreateItem and UpdateItem mirror fyne's widget.List pool pattern:
+	// Length reports the item count, CreateItem builds one reusable card, and
+	// UpdateItem rebinds a card to render item id. All three default to the
+	// built-in poster-card implementation but can be overridden.
+	Length     func() int
+	CreateItem func() fyne.CanvasObject
+	UpdateItem func(id int, obj fyne.CanvasObject)
+
+	poolMu       sync.Mutex
+	pool         []*poolSlot
+	gridContent  *fyne.Container // virtual-sized container holding the pooled cards
 	scrollBar    *container.Scroll
-	visibleCards []fyne.CanvasObject // Cache rendered cards
-	imageCache   map[string]fyne.Resource
-	imageCacheMu sync.RWMutex
-	selectedIdx  int
+	imageCache   *imagecache.Cache
+	providers    *poster.Registry
 	loadCtx      context.Context
 	loadCancel   context.CancelFunc
 	pendingLoads sync.WaitGroup
-	renderCtx    context.Context
-	renderCancel context.CancelFunc
-	isRendering  bool
-	renderMu     sync.Mutex
 	onRefresh    func()
 	progressBar  *widget.ProgressBarInfinite
 	statusLabel  *widget.Label
 	mainContent  *fyne.Container
+
+	// actions is this grid's keyboard shortcut table (see bindTables in mediagrid.go);
+	// keyActions/runeActions/shortcutActions are derived from it for dispatch.
+	actions         []*Action
+	keyActions      map[fyne.KeyName]*Action
+	runeActions     map[rune]*Action
+	shortcutActions []*Action
+	canvasShortcuts []fyne.Shortcut
+	captureAction   *Action      // non-nil while the preferences dialog awaits a key press
+	onCapture       func(binding string)
+
+	searchPopup *widget.PopUp
+	searchEntry *widget.Entry
+	filterPopup *widget.PopUp
+
+	// detailsPanel/detailsScroll show metadata and a Play button for the
+	// non-directory item last tapped (see showDetails); detailsVisible controls
+	// whether updateCenterContent pairs it alongside the grid via an HSplit.
+	detailsNode    *tree.Node
+	detailsVisible bool
+	detailsPanel   *fyne.Container
+	detailsScroll  *container.Scroll
+
+	// PlayerCommand is the executable Play launches a selected video with, given its
+	// path as the sole argument. Defaults to a platform-appropriate opener.
+	PlayerCommand string
 }
 
-// NewMediaGrid creates a new media grid widget
-func NewMediaGrid(t *tree.Tree, cols int, win fyne.Window) *MediaGrid {
+// NewMediaGrid creates a new media grid widget backed by the given shared image
+// cache (see ui/imagecache; pass the same *imagecache.Cache to every grid in a
+// LibraryWorkspace so posters decoded in one tab are reused in another).
+func NewMediaGrid(t *tree.Tree, cols int, win fyne.Window, cache *imagecache.Cache) *MediaGrid {
 	ctx, cancel := context.WithCancel(context.Background())
-	renderCtx, renderCancel := context.WithCancel(context.Background())
 
 	g := &MediaGrid{
-		tree:         t,
-		window:       win,
-		cols:         cols,
-		rowHeight:    200,
-		colWidth:     150,
-		batchSize:    50, // Render in smaller batches
-		items:        make([]*MediaItem, 0),
-		imageCache:   make(map[string]fyne.Resource),
-		loadCtx:      ctx,
-		loadCancel:   cancel,
-		renderCtx:    renderCtx,
-		renderCancel: renderCancel,
-		progressBar:  widget.NewProgressBarInfinite(),
-		statusLabel:  widget.NewLabel("Loading media library..."),
+		tree:          t,
+		window:        win,
+		cols:          cols,
+		rowHeight:     200,
+		colWidth:      150,
+		items:         make([]*MediaItem, 0),
+		imageCache:    cache,
+		loadCtx:       ctx,
+		loadCancel:    cancel,
+		progressBar:   widget.NewProgressBarInfinite(),
+		statusLabel:   widget.NewLabel("Loading media library..."),
+		PlayerCommand: defaultPlayerCommand(),
 	}
 
+	g.Length = func() int { return len(g.items) }
+	g.CreateItem = g.createPooledCard
+	g.UpdateItem = g.updatePooledCard
+
 	g.ExtendBaseWidget(g)
+	g.bindTables(nil)
 	return g
 }
 
+// SetItemSize sets the size of one card, recomputes cols from the current container
+// width (instead of leaving it pinned), and triggers a re-layout.
+func (g *MediaGrid) SetItemSize(size fyne.Size) {
+	g.colWidth = size.Width
+	g.rowHeight = size.Height
+	g.recomputeCols()
+	g.layoutVisible()
+}
+
+// Resize recomputes the column count from the new width (so the grid reflows
+// instead of staying pinned to its construction-time column count) before
+// delegating to BaseWidget.
+func (g *MediaGrid) Resize(size fyne.Size) {
+	g.BaseWidget.Resize(size)
+	g.recomputeCols()
+	g.layoutVisible()
+}
+
+func (g *MediaGrid) recomputeCols() {
+	if g.colWidth <= 0 {
+		return
+	}
+	width := g.Size().Width
+	if width <= 0 {
+		return
+	}
+	cols := int(width / g.colWidth)
+	if cols < 1 {
+		cols = 1
+	}
+	g.cols = cols
+}
+
 // CreateRenderer creates the widget renderer
 func (g *MediaGrid) CreateRenderer() fyne.WidgetRenderer {
-	g.container = container.NewVBox()
+	g.gridContent = container.NewWithoutLayout()
 
 	// Create loading view
 	loadingView := container.NewVBox(
@@ -110,7 +193,8 @@ func (g *MediaGrid) UpdateProgress(files, dirs int64) {
 	g.statusLabel.SetText(fmt.Sprintf("Found %d directories and %d files", dirs, files))
 }
 
-// Refresh rebuilds the grid from the virtual filesystem
+// Refresh rebuilds the grid's item list from the virtual filesystem and re-lays out
+// the pooled cards over whatever portion of it is currently visible.
 func (g *MediaGrid) Refresh() {
 	if g.tree.IsLoading() && len(g.tree.VisibleNodes) == 0 {
 		g.progressBar.Start()
@@ -119,28 +203,41 @@ func (g *MediaGrid) Refresh() {
 
 	g.progressBar.Stop()
 
-	// Cancel any ongoing progressive rendering
-	if g.renderCancel != nil {
-		g.renderCancel()
-	}
-	g.renderCtx, g.renderCancel = context.WithCancel(context.Background())
-
-	// Build media items from virtual filesystem (tree in memory)
 	g.buildMediaItems()
+	g.recomputeCols()
 
-	// Render initial batch
-	g.renderVisibleBatch()
+	if g.scrollBar == nil {
+		g.scrollBar = container.NewVScroll(g.gridContent)
+		g.scrollBar.OnScrolled = func(fyne.Position) { g.layoutVisible() }
+	}
 
-	log.Printf("Rendered initial batch %d-%d of %d items",
-		g.visibleStart, g.visibleEnd, len(g.items))
+	g.updateCenterContent()
+	g.layoutVisible()
+	g.mainContent.Refresh()
+}
 
-	// Start progressive rendering in background
-	go g.progressiveRender()
+// updateCenterContent swaps g.mainContent's single child between the bare poster grid
+// scroll and an HSplit pairing it with the details panel, depending on detailsVisible.
+// The grid and the details panel are each their own container.Scroll, so Fyne's
+// normal per-Scroll clipping keeps the panel's scrollbar/hover region from bleeding
+// into the grid's (or vice versa) without any manual clip-rect composition.
+func (g *MediaGrid) updateCenterContent() {
+	if g.detailsVisible && g.detailsScroll != nil {
+		g.mainContent.Objects = []fyne.CanvasObject{container.NewHSplit(g.scrollBar, g.detailsScroll)}
+		return
+	}
+	g.mainContent.Objects = []fyne.CanvasObject{g.scrollBar}
 }
 
-// buildMediaItems creates MediaItem wrappers for visible nodes
+// buildMediaItems creates MediaItem wrappers for the tree's normal visible nodes.
 func (g *MediaGrid) buildMediaItems() {
-	nodes := g.tree.VisibleNodes
+	g.buildItemsFromNodes(g.tree.VisibleNodes)
+}
+
+// buildItemsFromNodes creates MediaItem wrappers for an explicit node list instead of
+// g.tree.VisibleNodes, so search results (see onSearchChanged) can be displayed
+// without disturbing the tree's own directory listing or SelectedIdx.
+func (g *MediaGrid) buildItemsFromNodes(nodes []*tree.Node) {
 	g.items = make([]*MediaItem, len(nodes))
 
 	for i, node := range nodes {
@@ -155,13 +252,29 @@ func (g *MediaGrid) buildMediaItems() {
 
 		g.items[i] = item
 
-		// Start async load if poster exists
-		if node.PosterPath != "" {
+		// Start async load if poster exists, otherwise fall back to the registered
+		// remote providers (if any) for non-directory items. pendingLoads.Add must
+		// happen here, before the goroutine starts, not inside it: otherwise Close's
+		// Wait could race ahead of a load that hasn't registered itself yet.
+		switch {
+		case node.PosterPath != "":
+			g.pendingLoads.Add(1)
 			go g.loadImageAsync(node.PosterPath, item)
+		case g.providers != nil && !node.IsDir:
+			g.pendingLoads.Add(1)
+			go g.loadRemotePosterAsync(node, item)
 		}
 	}
 }
 
+// SetPosterProviders installs a poster.Registry to fall back on for items the Tree
+// didn't already resolve a local poster for (node.PosterPath == ""). Call it before
+// the grid's first Refresh; it does not retroactively fetch posters for items already
+// bound to a placeholder.
+func (g *MediaGrid) SetPosterProviders(r *poster.Registry) {
+	g.providers = r
+}
+
 // getPlaceholderResource returns appropriate placeholder for node type
 func (g *MediaGrid) getPlaceholderResource(node *tree.Node) fyne.Resource {
 	if node.IsDir {
@@ -172,321 +285,241 @@ func (g *MediaGrid) getPlaceholderResource(node *tree.Node) fyne.Resource {
 	return theme.FileIcon()
 }
 
-// loadImageAsync loads an image and updates binding when ready
+// loadImageAsync submits a decode job to the shared imagecache.Cache and updates the
+// item's binding once it resolves. Cancelling g.loadCtx drops queued-but-not-started
+// jobs submitted this way instead of leaving them to run to completion. Callers must
+// have already called g.pendingLoads.Add(1) before spawning this as a goroutine.
 func (g *MediaGrid) loadImageAsync(posterPath string, item *MediaItem) {
-	g.pendingLoads.Add(1)
 	defer g.pendingLoads.Done()
 
-	// Check if cancelled
-	select {
-	case <-g.loadCtx.Done():
+	resource, err := g.imageCache.Get(g.loadCtx, posterPath, fyne.NewSize(g.colWidth, g.rowHeight))
+	if err != nil {
 		return
-	default:
 	}
 
-	// Check cache first
-	g.imageCacheMu.RLock()
-	if cached, exists := g.imageCache[posterPath]; exists {
-		g.imageCacheMu.RUnlock()
-		item.ImageBinding.Set(cached)
-		return
-	}
-	g.imageCacheMu.RUnlock()
+	item.ImageBinding.Set(resource)
+}
+
+// loadRemotePosterAsync tries g.providers for a poster when the Tree found none
+// locally, updating the item's binding the same way loadImageAsync does. Unlike the
+// local path, the fetched image isn't routed through imageCache: providers already
+// return reasonably-sized poster art, and HTTPProvider disk-caches it itself. Callers
+// must have already called g.pendingLoads.Add(1) before spawning this as a goroutine.
+func (g *MediaGrid) loadRemotePosterAsync(node *tree.Node, item *MediaItem) {
+	defer g.pendingLoads.Done()
 
-	// Load from disk
-	data, err := os.ReadFile(posterPath)
+	rc, err := g.providers.Lookup(g.loadCtx, node)
 	if err != nil {
 		return
 	}
+	defer rc.Close()
 
-	// Validate format
-	_, _, err = image.DecodeConfig(bytes.NewReader(data))
+	data, err := io.ReadAll(rc)
 	if err != nil {
 		return
 	}
 
-	// Check cancelled
-	select {
-	case <-g.loadCtx.Done():
+	item.ImageBinding.Set(fyne.NewStaticResource(node.Path, data))
+}
+
+// layoutVisible is the heart of the pool: it resizes gridContent to the grid's full
+// virtual size (so the scrollbar tracks the true item count), works out which item
+// ids are within the current viewport plus overscan, and reconciles the pool so
+// exactly those ids are bound and positioned, recycling slots bound to now-offscreen
+// ids instead of allocating new widgets.
+func (g *MediaGrid) layoutVisible() {
+	if g.gridContent == nil || g.scrollBar == nil || g.cols <= 0 {
 		return
-	default:
 	}
 
-	// Create resource
-	resource := fyne.NewStaticResource(posterPath, data)
+	total := g.Length()
+	rows := 0
+	if total > 0 {
+		rows = (total + g.cols - 1) / g.cols
+	}
+	g.gridContent.Resize(fyne.NewSize(float32(g.cols)*g.colWidth, float32(rows)*g.rowHeight))
+
+	if total == 0 {
+		return
+	}
 
-	// Cache it
-	g.imageCacheMu.Lock()
-	g.imageCache[posterPath] = resource
-	g.imageCacheMu.Unlock()
+	viewHeight := g.scrollBar.Size().Height
+	if viewHeight <= 0 {
+		viewHeight = g.rowHeight
+	}
 
-	// Update binding (this triggers UI update automatically)
-	item.ImageBinding.Set(resource)
-}
+	firstRow := int(g.scrollBar.Offset.Y/g.rowHeight) - overscanRows
+	if firstRow < 0 {
+		firstRow = 0
+	}
+	visibleRows := int(math.Ceil(float64(viewHeight/g.rowHeight))) + 1 + 2*overscanRows
+	lastRow := firstRow + visibleRows
+	if lastRow > rows {
+		lastRow = rows
+	}
 
-// renderVisibleBatch renders items in the current window
-func (g *MediaGrid) renderVisibleBatch() {
-	g.container.Objects = nil
+	startID := firstRow * g.cols
+	endID := lastRow * g.cols
+	if endID > total {
+		endID = total
+	}
 
-	// Add path header
-	pathLabel := widget.NewLabel("ðŸ“ " + g.tree.CurrentDir.Path)
-	pathLabel.Wrapping = fyne.TextWrapWord
-	g.container.Add(pathLabel)
+	g.poolMu.Lock()
+	defer g.poolMu.Unlock()
 
-	// Calculate visible range
-	totalItems := len(g.items)
-	g.visibleStart = 0
-	g.visibleEnd = totalItems
-	if totalItems > g.batchSize {
-		g.visibleEnd = g.batchSize
+	g.ensurePoolLocked(endID - startID)
 
-		infoLabel := widget.NewLabel(
-			fmt.Sprintf("Showing %d-%d of %d items (scroll for more)",
-				g.visibleStart+1, g.visibleEnd, totalItems))
-		g.container.Add(infoLabel)
+	bound := make(map[int]*poolSlot, endID-startID)
+	free := make([]*poolSlot, 0, len(g.pool))
+	for _, slot := range g.pool {
+		if slot.id >= startID && slot.id < endID {
+			bound[slot.id] = slot
+		} else {
+			free = append(free, slot)
+		}
 	}
 
-	// Create grid container for media items
-	gridContainer := container.New(layout.NewGridWrapLayout(fyne.NewSize(g.colWidth, g.rowHeight)))
+	freeIdx := 0
+	for id := startID; id < endID; id++ {
+		slot, ok := bound[id]
+		if !ok {
+			if freeIdx >= len(free) {
+				continue // ensurePoolLocked guarantees this shouldn't happen
+			}
+			slot = free[freeIdx]
+			freeIdx++
+			slot.id = id
+			g.UpdateItem(id, slot.obj)
+		}
 
-	// Add visible items
-	visibleCards := make([]fyne.CanvasObject, 0)
-	for i := g.visibleStart; i < g.visibleEnd && i < len(g.items); i++ {
-		item := g.items[i]
-		mediaCard := g.createMediaCard(item)
-		gridContainer.Add(mediaCard)
-		visibleCards = append(visibleCards, mediaCard)
+		row := id / g.cols
+		col := id % g.cols
+		slot.obj.Move(fyne.NewPos(float32(col)*g.colWidth, float32(row)*g.rowHeight))
+		slot.obj.Resize(fyne.NewSize(g.colWidth, g.rowHeight))
+		slot.obj.Show()
 	}
-	g.visibleCards = visibleCards
 
-	g.container.Add(gridContainer)
+	for ; freeIdx < len(free); freeIdx++ {
+		free[freeIdx].id = -1
+		free[freeIdx].obj.Hide()
+	}
 
-	// Update main content with scroll container
-	if g.scrollBar == nil {
-		g.scrollBar = container.NewVScroll(g.container)
-		g.mainContent.Objects = []fyne.CanvasObject{g.scrollBar}
-	} else {
-		g.scrollBar.Content = g.container
-		g.scrollBar.Refresh()
+	g.gridContent.Refresh()
+}
+
+// ensurePoolLocked grows the pool to at least size slots. Callers must hold poolMu.
+func (g *MediaGrid) ensurePoolLocked(size int) {
+	for len(g.pool) < size {
+		obj := g.CreateItem()
+		obj.Hide()
+		g.gridContent.Add(obj)
+		g.pool = append(g.pool, &poolSlot{obj: obj, id: -1})
 	}
-	g.mainContent.Refresh()
 }
 
-// createMediaCard creates a card for a media item with data-bound image
-func (g *MediaGrid) createMediaCard(item *MediaItem) fyne.CanvasObject {
-	// Create image canvas
+// createPooledCard builds one reusable card widget with no item bound yet.
+func (g *MediaGrid) createPooledCard() fyne.CanvasObject {
 	img := canvas.NewImageFromResource(theme.MediaVideoIcon())
 	img.FillMode = canvas.ImageFillContain
-	img.SetMinSize(fyne.NewSize(g.colWidth-10, g.rowHeight-40))
-
-	// Bind image to data binding
-	item.ImageBinding.AddListener(binding.NewDataListener(func() {
-		if val, err := item.ImageBinding.Get(); err == nil {
-			if resource, ok := val.(fyne.Resource); ok {
-				img.Resource = resource
-				img.Refresh()
-			}
-		}
-	}))
-
-	// Initial value
-	if val, err := item.ImageBinding.Get(); err == nil {
-		if resource, ok := val.(fyne.Resource); ok {
-			img.Resource = resource
-		}
-	}
 
-	// Create label
-	label := widget.NewLabel(item.Node.Name)
+	label := widget.NewLabel("")
 	label.Wrapping = fyne.TextWrapWord
 	label.Alignment = fyne.TextAlignCenter
 
-	// Create card container
-	card := container.NewBorder(
-		nil,
-		label,
-		nil,
-		nil,
-		img,
-	)
+	content := container.NewBorder(nil, label, nil, nil, img)
 
-	// Make it tappable
-	tappable := newTappableContainer(card, func() {
-		g.onItemTapped(item)
-	})
-
-	// Highlight if selected
-	if item.Index == g.tree.SelectedIdx {
-		tappable.(*tappableContainer).selected = true
+	card := &tappableContainer{content: content, img: img, label: label, boundID: -1}
+	card.ExtendBaseWidget(card)
+	card.onTapped = func() {
+		if card.boundID >= 0 && card.boundID < len(g.items) {
+			g.onItemTapped(g.items[card.boundID])
+		}
 	}
-
-	return tappable
+	return card
 }
 
-// progressiveRender renders remaining items in batches asynchronously
-func (g *MediaGrid) progressiveRender() {
-	g.renderMu.Lock()
-	if g.isRendering {
-		g.renderMu.Unlock()
-		return
-	}
-	g.isRendering = true
-	g.renderMu.Unlock()
-
-	defer func() {
-		g.renderMu.Lock()
-		g.isRendering = false
-		g.renderMu.Unlock()
-	}()
-
-	totalItems := len(g.items)
-	if g.visibleEnd >= totalItems {
+// updatePooledCard rebinds card (one of the pool's widgets) to item id, detaching
+// its previous ImageBinding listener and attaching a fresh one to the new item.
+func (g *MediaGrid) updatePooledCard(id int, obj fyne.CanvasObject) {
+	card, ok := obj.(*tappableContainer)
+	if !ok || id < 0 || id >= len(g.items) {
 		return
 	}
+	item := g.items[id]
 
-	// Get the grid container
-	var gridContainer *fyne.Container
-	if len(g.container.Objects) > 0 {
-		if c, ok := g.container.Objects[len(g.container.Objects)-1].(*fyne.Container); ok {
-			gridContainer = c
-		}
+	if card.listener != nil && card.boundBinding != nil {
+		card.boundBinding.RemoveListener(card.listener)
 	}
 
-	if gridContainer == nil {
-		return
-	}
+	card.boundID = id
+	card.img.SetMinSize(fyne.NewSize(g.colWidth-10, g.rowHeight-40))
+	card.label.SetText(item.Node.Name)
+	card.selected = id == g.tree.SelectedIdx
 
-	// Render remaining items in batches with small delays
-	for g.visibleEnd < totalItems {
-		// Check if cancelled
-		select {
-		case <-g.renderCtx.Done():
-			return
-		default:
+	if val, err := item.ImageBinding.Get(); err == nil {
+		if resource, ok := val.(fyne.Resource); ok {
+			card.img.Resource = resource
 		}
+	}
 
-		// Calculate next batch
-		batchStart := g.visibleEnd
-		batchEnd := min(batchStart+g.batchSize, totalItems)
-
-		// Update visible end immediately (before async UI update)
-		g.visibleEnd = batchEnd
-
-		// Batch all UI operations together
-		fyne.Do(func() {
-			// Add batch items
-			for i := batchStart; i < batchEnd; i++ {
-				item := g.items[i]
-				mediaCard := g.createMediaCard(item)
-				gridContainer.Add(mediaCard)
-				g.visibleCards = append(g.visibleCards, mediaCard)
-			}
-
-			// Update info label
-			if len(g.container.Objects) >= 2 {
-				if label, ok := g.container.Objects[1].(*widget.Label); ok {
-					if batchEnd < totalItems {
-						label.SetText(fmt.Sprintf("Showing %d-%d of %d items (loading...)",
-							g.visibleStart+1, batchEnd, totalItems))
-					} else {
-						label.SetText(fmt.Sprintf("Showing all %d items", totalItems))
-					}
-				}
-			}
-
-			// Refresh grid
-			gridContainer.Refresh()
-			g.scrollBar.Content.Refresh()
-		})
-
-		log.Printf("Progressive render: added batch %d-%d (total: %d)", batchStart, batchEnd, totalItems)
-
-		// Small delay to avoid overwhelming UI thread
-		if batchEnd < totalItems {
-			timer := time.NewTimer(100 * time.Millisecond)
-			select {
-			case <-g.renderCtx.Done():
-				timer.Stop()
-				return
-			case <-timer.C:
-				// Continue to next batch
+	listener := binding.NewDataListener(func() {
+		if val, err := item.ImageBinding.Get(); err == nil {
+			if resource, ok := val.(fyne.Resource); ok {
+				card.img.Resource = resource
+				card.img.Refresh()
 			}
 		}
-	}
+	})
+	item.ImageBinding.AddListener(listener)
+	card.listener = listener
+	card.boundBinding = item.ImageBinding
+
+	card.Refresh()
 }
 
-// updateSelection updates only the selection highlight without rebuilding the grid
+// updateSelection updates only the selection highlight, refreshing whichever pooled
+// cards currently happen to be bound to oldIdx/newIdx.
 func (g *MediaGrid) updateSelection(oldIdx, newIdx int) {
-	// Update within visible range
-	if oldIdx >= g.visibleStart && oldIdx < g.visibleEnd {
-		cardIdx := oldIdx - g.visibleStart
-		if cardIdx >= 0 && cardIdx < len(g.visibleCards) {
-			if card, ok := g.visibleCards[cardIdx].(*tappableContainer); ok {
-				card.selected = false
-				card.Refresh()
-			}
+	g.poolMu.Lock()
+	for _, slot := range g.pool {
+		if slot.id != oldIdx && slot.id != newIdx {
+			continue
 		}
-	}
-
-	if newIdx >= g.visibleStart && newIdx < g.visibleEnd {
-		cardIdx := newIdx - g.visibleStart
-		if cardIdx >= 0 && cardIdx < len(g.visibleCards) {
-			if card, ok := g.visibleCards[cardIdx].(*tappableContainer); ok {
-				card.selected = true
-				card.Refresh()
-			}
+		if card, ok := slot.obj.(*tappableContainer); ok {
+			card.selected = slot.id == newIdx
+			card.Refresh()
 		}
 	}
+	g.poolMu.Unlock()
 
-	// Scroll to keep selected item visible (items will render progressively)
 	g.scrollToSelection(newIdx)
 }
 
-// scrollToSelection ensures the selected item is visible in the scroll view
+// scrollToSelection ensures the selected item's row is visible in the scroll view.
 func (g *MediaGrid) scrollToSelection(idx int) {
-	if g.scrollBar == nil || idx < 0 || idx >= len(g.items) {
+	if g.scrollBar == nil || g.cols <= 0 || idx < 0 || idx >= len(g.items) {
 		return
 	}
 
-	// Only scroll if item is actually rendered
-	if idx >= g.visibleEnd {
-		return // Item not rendered yet
-	}
-
-	// Calculate the actual rendered position based on visible cards
-	cardIdx := idx - g.visibleStart
-	if cardIdx < 0 || cardIdx >= len(g.visibleCards) {
-		return
-	}
-
-	// Calculate position based on rendered rows
 	row := idx / g.cols
 	rowY := float32(row) * g.rowHeight
 
-	if g.scrollBar.Content == nil {
-		return
-	}
-
 	viewHeight := g.scrollBar.Size().Height
 	currentOffset := g.scrollBar.Offset.Y
 
-	// Define visible region with padding
-	topThreshold := currentOffset + 50                               // 50px from top
-	bottomThreshold := currentOffset + viewHeight - g.rowHeight - 50 // 50px from bottom
+	topThreshold := currentOffset + 50
+	bottomThreshold := currentOffset + viewHeight - g.rowHeight - 50
 
-	// Check if item is outside comfortable viewing area
-	needsScroll := false
 	var newOffset float32
+	needsScroll := false
 
 	if rowY < topThreshold {
-		// Item is too close to top or above - center it in upper portion
 		newOffset = rowY - g.rowHeight
 		if newOffset < 0 {
 			newOffset = 0
 		}
 		needsScroll = true
 	} else if rowY > bottomThreshold {
-		// Item is too close to bottom or below - center it in lower portion
 		newOffset = rowY - viewHeight + g.rowHeight*2
 		if newOffset < 0 {
 			newOffset = 0
@@ -496,24 +529,22 @@ func (g *MediaGrid) scrollToSelection(idx int) {
 
 	if needsScroll {
 		g.scrollBar.ScrollToOffset(fyne.NewPos(0, newOffset))
+		g.layoutVisible()
 	}
 }
 
-// tappableContainer wraps a container to make it tappable
+// tappableContainer wraps a media card's content to make it tappable, carry its
+// selection highlight, and track which pool slot / item id it currently renders.
 type tappableContainer struct {
 	widget.BaseWidget
-	content  fyne.CanvasObject
-	onTapped func()
-	selected bool
-}
-
-func newTappableContainer(content fyne.CanvasObject, onTapped func()) fyne.CanvasObject {
-	t := &tappableContainer{
-		content:  content,
-		onTapped: onTapped,
-	}
-	t.ExtendBaseWidget(t)
-	return t
+	content      fyne.CanvasObject
+	onTapped     func()
+	selected     bool
+	img          *canvas.Image
+	label        *widget.Label
+	boundID      int
+	listener     binding.DataListener
+	boundBinding binding.Untyped
 }
 
 func (t *tappableContainer) CreateRenderer() fyne.WidgetRenderer {
@@ -578,45 +609,482 @@ func (g *MediaGrid) onItemTapped(item *MediaItem) {
 		if g.onRefresh != nil {
 			g.onRefresh()
 		}
-	} else {
-		log.Printf("Selected: %s", item.Node.Path)
+		return
 	}
+
+	g.showDetails(item.Node)
+}
+
+// defaultPlayerCommand picks a platform-appropriate opener, overridable via the
+// GMP_PLAYER environment variable (e.g. to point at mpv or vlc instead).
+func defaultPlayerCommand() string {
+	if p := os.Getenv("GMP_PLAYER"); p != "" {
+		return p
+	}
+	switch runtime.GOOS {
+	case "darwin":
+		return "open"
+	case "windows":
+		return "start"
+	default:
+		return "xdg-open"
+	}
+}
+
+// showDetails (re)builds the details panel for node and reveals it alongside the
+// grid; only called for non-directory items (see onItemTapped).
+func (g *MediaGrid) showDetails(node *tree.Node) {
+	g.detailsNode = node
+	g.rebuildDetailsPanel()
+	g.detailsVisible = true
+	g.updateCenterContent()
+	g.mainContent.Refresh()
 }
 
-// TypedKey handles keyboard navigation
+// hideDetails collapses the details panel back out of view and hands keyboard focus
+// back to the grid.
+func (g *MediaGrid) hideDetails() {
+	g.detailsVisible = false
+	g.updateCenterContent()
+	g.mainContent.Refresh()
+	g.window.Canvas().Unfocus()
+}
+
+// rebuildDetailsPanel rebuilds g.detailsPanel's contents for g.detailsNode: a poster,
+// filename, whatever metadata the Tree resolved for it, file size/mtime, and a Play
+// button that shells out to PlayerCommand.
+func (g *MediaGrid) rebuildDetailsPanel() {
+	node := g.detailsNode
+
+	if g.detailsPanel == nil {
+		g.detailsPanel = container.NewVBox()
+		g.detailsScroll = container.NewVScroll(g.detailsPanel)
+		g.detailsScroll.SetMinSize(fyne.NewSize(260, 0))
+	}
+
+	img := canvas.NewImageFromResource(g.getPlaceholderResource(node))
+	img.FillMode = canvas.ImageFillContain
+	img.SetMinSize(fyne.NewSize(240, 320))
+	g.loadDetailsPosterAsync(node, img)
+
+	rows := []fyne.CanvasObject{
+		img,
+		widget.NewLabelWithStyle(node.Name, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+	}
+
+	if meta := node.Metadata; meta != nil {
+		if meta.Title != "" {
+			rows = append(rows, widget.NewLabel("Title: "+meta.Title))
+		}
+		if meta.Year != 0 {
+			rows = append(rows, widget.NewLabel(fmt.Sprintf("Year: %d", meta.Year)))
+		}
+		if meta.Rating != 0 {
+			rows = append(rows, widget.NewLabel(fmt.Sprintf("Rating: %.1f", meta.Rating)))
+		}
+		if meta.Plot != "" {
+			plot := widget.NewLabel(meta.Plot)
+			plot.Wrapping = fyne.TextWrapWord
+			rows = append(rows, plot)
+		}
+	}
+
+	if info, err := os.Stat(node.Path); err == nil {
+		rows = append(rows,
+			widget.NewLabel("Size: "+formatBytes(info.Size())),
+			widget.NewLabel("Modified: "+info.ModTime().Format("2006-01-02 15:04")),
+		)
+	}
+
+	rows = append(rows, widget.NewButton("Play", func() { g.playNode(node) }))
+
+	g.detailsPanel.Objects = rows
+	g.detailsPanel.Refresh()
+}
+
+// loadDetailsPosterAsync resolves a poster for node, scaled to 240x320, the same way
+// buildItemsFromNodes does for grid thumbnails (local PosterPath first, then the
+// registered remote providers), updating img via a binding.Untyped listener so the
+// decode can run off the UI goroutine. Registered with pendingLoads like every other
+// async load, so Close waits for it instead of letting it write into img after the
+// grid (and its window) may already be torn down.
+func (g *MediaGrid) loadDetailsPosterAsync(node *tree.Node, img *canvas.Image) {
+	b := binding.NewUntyped()
+	b.AddListener(binding.NewDataListener(func() {
+		val, err := b.Get()
+		if err != nil {
+			return
+		}
+		res, ok := val.(fyne.Resource)
+		if !ok {
+			return
+		}
+		img.Resource = res
+		img.Refresh()
+	}))
+
+	g.pendingLoads.Add(1)
+	go func() {
+		defer g.pendingLoads.Done()
+
+		size := fyne.NewSize(240, 320)
+		if node.PosterPath != "" {
+			if res, err := g.imageCache.Get(g.loadCtx, node.PosterPath, size); err == nil {
+				b.Set(res)
+				return
+			}
+		}
+		if g.providers == nil {
+			return
+		}
+		rc, err := g.providers.Lookup(g.loadCtx, node)
+		if err != nil {
+			return
+		}
+		defer rc.Close()
+		if data, err := io.ReadAll(rc); err == nil {
+			b.Set(fyne.NewStaticResource(node.Path, data))
+		}
+	}()
+}
+
+// playNode shells out to PlayerCommand with node's path, logging (rather than
+// blocking the UI on) any failure to launch it.
+func (g *MediaGrid) playNode(node *tree.Node) {
+	if err := exec.Command(g.PlayerCommand, node.Path).Start(); err != nil {
+		log.Printf("mediagrid: failed to launch %s %s: %v", g.PlayerCommand, node.Path, err)
+	}
+}
+
+// formatBytes renders n as a human-readable size, e.g. "482.3 MiB".
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// TypedKey dispatches a bare (unmodified) key press to its bound Action, via
+// g.keyActions (see bindTables). While the preferences dialog is waiting for a key
+// to rebind, the press is captured instead of dispatched.
 func (g *MediaGrid) TypedKey(key *fyne.KeyEvent) {
-	oldIdx := g.tree.SelectedIdx
+	if g.captureAction != nil {
+		g.finishCapture((&Action{Key: key.Name}).binding())
+		return
+	}
+	if a, ok := g.keyActions[key.Name]; ok {
+		a.Do(g)
+	}
+}
 
-	switch key.Name {
-	case fyne.KeyUp:
-		g.tree.NavigateUp(g.cols)
-	case fyne.KeyDown:
-		g.tree.NavigateDown(g.cols)
-	case fyne.KeyLeft:
-		g.tree.NavigateLeft()
-	case fyne.KeyRight:
-		g.tree.NavigateRight()
-	case fyne.KeyReturn, fyne.KeyEnter:
-		if g.tree.SelectedIdx >= 0 && g.tree.SelectedIdx < len(g.items) {
-			g.onItemTapped(g.items[g.tree.SelectedIdx])
+// TypedRune dispatches a printable character key press to its bound Action, via
+// g.runeActions (see bindTables).
+func (g *MediaGrid) TypedRune(r rune) {
+	if g.captureAction != nil {
+		g.finishCapture(string(r))
+		return
+	}
+	if a, ok := g.runeActions[r]; ok {
+		a.Do(g)
+	}
+}
+
+// bindTables (re)builds actions (defaultActions() if nil) into this grid's dispatch
+// tables, applying any rebindings saved in fyne.Preferences first. Bare Key and Rune
+// actions are dispatched from TypedKey/TypedRune above; Key+Mod actions (e.g. Ctrl+F)
+// are collected into shortcutActions for ActivateShortcuts to register as canvas
+// shortcuts, since Fyne only routes those to AddShortcut, not to the focused widget's
+// TypedKey. This does not touch the canvas itself, so it's safe to call for a grid
+// whose tab isn't the active one.
+func (g *MediaGrid) bindTables(actions []*Action) {
+	if actions == nil {
+		actions = defaultActions()
+	}
+	loadBindings(actions)
+
+	g.actions = actions
+	g.keyActions = make(map[fyne.KeyName]*Action, len(actions))
+	g.runeActions = make(map[rune]*Action, len(actions))
+	g.shortcutActions = g.shortcutActions[:0]
+
+	for _, a := range actions {
+		switch {
+		case a.Rune != 0:
+			g.runeActions[a.Rune] = a
+		case a.Mod != 0:
+			g.shortcutActions = append(g.shortcutActions, a)
+		default:
+			g.keyActions[a.Key] = a
 		}
+	}
+
+	// KeyEnter is a long-standing alias for "open" (bound to KeyReturn) on keyboards
+	// with a separate numpad Enter key.
+	if a, ok := findAction(actions, "open"); ok {
+		g.keyActions[fyne.KeyEnter] = a
+	}
+}
+
+// ActivateShortcuts registers this grid's Key+Mod actions (see bindTables) as
+// shortcuts on the shared window canvas. The canvas is shared by every tab in a
+// LibraryWorkspace, so exactly one grid's shortcuts must be active at a time: call
+// this when the grid's tab becomes the active one, and DeactivateShortcuts when it
+// stops being active (see LibraryWorkspace.selectTab/closeTab), or the
+// most-recently-activated tab silently steals every other tab's Key+Mod bindings.
+func (g *MediaGrid) ActivateShortcuts() {
+	canvas := g.window.Canvas()
+	for _, a := range g.shortcutActions {
+		a := a
+		sc := &desktop.CustomShortcut{KeyName: a.Key, Modifier: a.Mod}
+		canvas.AddShortcut(sc, func(fyne.Shortcut) { a.Do(g) })
+		g.canvasShortcuts = append(g.canvasShortcuts, sc)
+	}
+}
+
+// DeactivateShortcuts removes this grid's canvas shortcuts (see ActivateShortcuts).
+func (g *MediaGrid) DeactivateShortcuts() {
+	canvas := g.window.Canvas()
+	for _, sc := range g.canvasShortcuts {
+		canvas.RemoveShortcut(sc)
+	}
+	g.canvasShortcuts = nil
+}
+
+// finishCapture applies a freshly captured key press as g.captureAction's new
+// binding, persists it, and rebuilds the dispatch tables so it takes effect
+// immediately. Only bare keys and runes can be captured this way, since modified
+// combinations are consumed by the canvas shortcut system before reaching TypedKey.
+func (g *MediaGrid) finishCapture(binding string) {
+	a := g.captureAction
+	g.captureAction = nil
+
+	if err := a.applyBinding(binding); err != nil {
+		fyne.LogError("ui: rebind failed", err)
 		return
-	case fyne.KeyBackspace:
-		g.tree.GoUp()
+	}
+	saveBinding(a)
+
+	// Rebinding only happens via the preferences dialog, which is only reachable from
+	// the active tab, so it's safe to assume this grid's shortcuts are currently live.
+	g.DeactivateShortcuts()
+	g.bindTables(g.actions)
+	g.ActivateShortcuts()
+
+	if g.onCapture != nil {
+		g.onCapture(a.binding())
+	}
+}
+
+// moveSelection runs a Tree navigation method and, if it moved SelectedIdx, refreshes
+// the selection highlight and scroll position to match.
+func (g *MediaGrid) moveSelection(navigate func()) {
+	oldIdx := g.tree.SelectedIdx
+	navigate()
+	if g.tree.SelectedIdx != oldIdx {
+		g.updateSelection(oldIdx, g.tree.SelectedIdx)
+	}
+}
+
+func (g *MediaGrid) doOpenSelected() {
+	if g.tree.SelectedIdx >= 0 && g.tree.SelectedIdx < len(g.items) {
+		g.onItemTapped(g.items[g.tree.SelectedIdx])
+	}
+}
+
+func (g *MediaGrid) doGoUp() {
+	g.tree.GoUp()
+	g.Refresh()
+}
+
+func (g *MediaGrid) selectFirst() {
+	if len(g.items) > 0 {
+		g.tree.SelectedIdx = 0
+	}
+}
+
+func (g *MediaGrid) selectLast() {
+	if len(g.items) > 0 {
+		g.tree.SelectedIdx = len(g.items) - 1
+	}
+}
+
+// visibleRows estimates how many grid rows fit in the current viewport, for paging.
+func (g *MediaGrid) visibleRows() int {
+	if g.scrollBar == nil || g.rowHeight <= 0 {
+		return 1
+	}
+	if rows := int(g.scrollBar.Size().Height / g.rowHeight); rows > 1 {
+		return rows
+	}
+	return 1
+}
+
+func (g *MediaGrid) pageUp() { g.pageBy(-g.visibleRows()) }
+
+func (g *MediaGrid) pageDown() { g.pageBy(g.visibleRows()) }
+
+// pageBy moves the selection rows rows (negative to page up), clamping to the item
+// list and preserving the current column.
+func (g *MediaGrid) pageBy(rows int) {
+	if len(g.items) == 0 || g.cols <= 0 {
+		return
+	}
+	idx := g.tree.SelectedIdx + rows*g.cols
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(g.items) {
+		idx = len(g.items) - 1
+	}
+	g.tree.SelectedIdx = idx
+}
+
+// doFocusSearch opens (or refocuses) a floating search box that narrows the grid down
+// to tree.Search's fuzzy matches as the user types; clearing it (or Escape) restores
+// the normal directory listing.
+func (g *MediaGrid) doFocusSearch() {
+	if g.searchPopup == nil {
+		g.searchEntry = widget.NewEntry()
+		g.searchEntry.SetPlaceHolder("Search…")
+		g.searchEntry.OnChanged = g.onSearchChanged
+
+		g.searchPopup = widget.NewModalPopUp(widget.NewCard("", "", g.searchEntry), g.window.Canvas())
+	}
+
+	g.searchPopup.Resize(fyne.NewSize(360, 70))
+	g.searchPopup.Show()
+	g.window.Canvas().Focus(g.searchEntry)
+}
+
+// onSearchChanged narrows the grid to query's fuzzy matches, or restores the normal
+// directory listing once query is cleared.
+func (g *MediaGrid) onSearchChanged(query string) {
+	if strings.TrimSpace(query) == "" {
 		g.Refresh()
 		return
 	}
 
-	// Update selection visually if changed
-	if oldIdx != g.tree.SelectedIdx {
-		g.updateSelection(oldIdx, g.tree.SelectedIdx)
+	results := g.tree.Search(query, tree.SearchOptions{Limit: 200})
+	nodes := make([]*tree.Node, len(results))
+	for i, r := range results {
+		nodes[i] = r.Node
+	}
+
+	g.buildItemsFromNodes(nodes)
+	g.recomputeCols()
+	g.layoutVisible()
+	g.mainContent.Refresh()
+}
+
+// closeSearch hides the search popup and clears its query, which restores the normal
+// directory listing via onSearchChanged. Keyboard events go through the window-level
+// SetOnTypedKey hook (see main.go), not Fyne's focus system, so unfocusing the search
+// entry is all that's needed to hand keys back to the grid.
+func (g *MediaGrid) closeSearch() {
+	if g.searchPopup != nil {
+		g.searchPopup.Hide()
+		g.searchEntry.SetText("")
+	}
+	g.window.Canvas().Unfocus()
+}
+
+// doToggleFilterSidebar shows or hides a small popup for switching the tree's
+// ViewMode (see tree.SetViewMode) without leaving the keyboard.
+func (g *MediaGrid) doToggleFilterSidebar() {
+	if g.filterPopup == nil {
+		group := widget.NewRadioGroup([]string{"All", "Flat Videos"}, func(choice string) {
+			if choice == "Flat Videos" {
+				g.tree.SetViewMode(tree.ViewFlatVideos)
+			} else {
+				g.tree.SetViewMode(tree.ViewNormal)
+			}
+			g.Refresh()
+		})
+		if g.tree.ViewMode() == tree.ViewFlatVideos {
+			group.SetSelected("Flat Videos")
+		} else {
+			group.SetSelected("All")
+		}
+
+		g.filterPopup = widget.NewPopUp(widget.NewCard("View", "", group), g.window.Canvas())
+	}
+
+	if g.filterPopup.Visible() {
+		g.filterPopup.Hide()
+		return
+	}
+	g.filterPopup.Resize(fyne.NewSize(200, 120))
+	g.filterPopup.Move(fyne.NewPos(g.Size().Width-210, 10))
+	g.filterPopup.Show()
+}
+
+// doEscape closes whichever of the search popup, filter popup, or details panel is
+// currently open, in that priority order.
+func (g *MediaGrid) doEscape() {
+	if g.searchPopup != nil && g.searchPopup.Visible() {
+		g.closeSearch()
+		return
+	}
+	if g.filterPopup != nil && g.filterPopup.Visible() {
+		g.filterPopup.Hide()
+		return
+	}
+	if g.detailsVisible {
+		g.hideDetails()
 	}
 }
 
-// TypedRune implements Focusable
-func (g *MediaGrid) TypedRune(r rune) {}
+// doShowHelp lists every bound action alongside its current key combination.
+func (g *MediaGrid) doShowHelp() {
+	rows := container.NewVBox()
+	for _, a := range g.actions {
+		rows.Add(widget.NewLabel(fmt.Sprintf("%-14s  %s", a.binding(), a.Desc)))
+	}
+	dialog.ShowCustom("Keyboard Shortcuts", "Close", container.NewVScroll(rows), g.window)
+}
+
+// doShowPreferences opens a dialog listing every action with a "Rebind" button: press
+// it, then press the new key to rebind, persisted via fyne.Preferences. Only bare
+// keys and printable characters can be captured this way (see finishCapture).
+func (g *MediaGrid) doShowPreferences() {
+	rows := container.NewVBox()
+
+	for _, a := range g.actions {
+		a := a
+		bindingLabel := widget.NewLabel(a.binding())
+
+		rebind := widget.NewButton("Rebind", nil)
+		rebind.OnTapped = func() {
+			rebind.SetText("Press a key…")
+			g.captureAction = a
+			g.onCapture = func(binding string) {
+				bindingLabel.SetText(binding)
+				rebind.SetText("Rebind")
+			}
+		}
+
+		rows.Add(container.NewBorder(nil, nil, widget.NewLabel(a.Desc), container.NewHBox(bindingLabel, rebind)))
+	}
+
+	dialog.ShowCustom("Rebind Shortcuts", "Done", container.NewVScroll(rows), g.window)
+}
 
 // SetOnRefresh sets callback for refresh events
 func (g *MediaGrid) SetOnRefresh(callback func()) {
 	g.onRefresh = callback
 }
+
+// Close cancels this grid's pending poster loads and waits for them to exit, and
+// removes its canvas shortcuts. The image cache is shared across the workspace's
+// grids (see NewMediaGrid), so it isn't touched here.
+func (g *MediaGrid) Close() {
+	g.DeactivateShortcuts()
+	g.loadCancel()
+	g.pendingLoads.Wait()
+}
@@ -0,0 +1,188 @@
+package ui
+
+import (
+	"context"
+	"log"
+	"path/filepath"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+
+	"github.com/bruno-domazet/go-media-posters/poster"
+	"github.com/bruno-domazet/go-media-posters/tree"
+	"github.com/bruno-domazet/go-media-posters/ui/imagecache"
+)
+
+// LibraryTab owns one loaded library root: its Tree, the MediaGrid rendering it, and
+// the DocTabs item it's displayed in.
+type LibraryTab struct {
+	Root   string
+	Tree   *tree.Tree
+	Grid   *MediaGrid
+	item   *container.TabItem
+	cancel context.CancelFunc
+}
+
+// LibraryWorkspace hosts multiple concurrently loaded library roots as closable
+// DocTabs (e.g. Movies / TV / Anime side by side), each with its own *tree.Tree and
+// *MediaGrid, instead of limiting the app to a single folder per window.
+type LibraryWorkspace struct {
+	window    fyne.Window
+	filter    tree.Filter
+	cache     tree.CacheBackend
+	imgCache  *imagecache.Cache
+	providers *poster.Registry
+	docTabs   *container.DocTabs
+	tabs      []*LibraryTab
+	active    *LibraryTab
+}
+
+// NewLibraryWorkspace builds a workspace with one tab per root, all sharing filter and
+// a single imagecache.Cache so a poster decoded in one tab is reused in another. cache,
+// if non-nil, backs every tab's Tree's directory listings (see tree.LoadAsync); pass
+// nil to use the default in-memory TTL cache. providers, if non-nil, is consulted by
+// every tab's MediaGrid for items the Tree didn't resolve a local poster for; pass nil
+// to disable remote poster lookups.
+func NewLibraryWorkspace(roots []string, filter tree.Filter, cache tree.CacheBackend, win fyne.Window, providers *poster.Registry) *LibraryWorkspace {
+	w := &LibraryWorkspace{window: win, filter: filter, cache: cache, imgCache: imagecache.New(0), providers: providers}
+
+	w.docTabs = container.NewDocTabs()
+	w.docTabs.CreateTab = w.promptNewRoot
+	w.docTabs.OnClosed = w.closeTab
+	w.docTabs.OnSelected = w.selectTab
+
+	for _, root := range roots {
+		w.addRootInto(nil, root)
+	}
+
+	return w
+}
+
+// CanvasObject returns the workspace's root object for embedding in a window.
+func (w *LibraryWorkspace) CanvasObject() fyne.CanvasObject {
+	return w.docTabs
+}
+
+// TypedKey routes a key event to the active tab's grid, so keyboard navigation and
+// shortcuts always apply to whichever library is currently in front.
+func (w *LibraryWorkspace) TypedKey(key *fyne.KeyEvent) {
+	if w.active != nil {
+		w.active.Grid.TypedKey(key)
+	}
+}
+
+// addRootInto loads root and displays it in item, creating a new DocTabs item when
+// item is nil. The grid is forward-declared so LoadAsync's progress/completion
+// callbacks can reach it once loading is underway.
+func (w *LibraryWorkspace) addRootInto(item *container.TabItem, root string) *LibraryTab {
+	var grid *MediaGrid
+
+	onProgress := func(p tree.ProgressEvent) {
+		if grid != nil {
+			grid.UpdateProgress(p.Files, p.Dirs)
+		}
+	}
+	onComplete := func() {
+		if grid != nil {
+			grid.Refresh()
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	filterCopy := w.filter
+	fileTree, err := tree.LoadAsync(ctx, root, &filterCopy, w.cache, onProgress, onComplete)
+	if err != nil {
+		cancel()
+		log.Printf("workspace: failed to load %s: %v", root, err)
+		return nil
+	}
+
+	grid = NewMediaGrid(fileTree, 4, w.window, w.imgCache)
+	if w.providers != nil {
+		grid.SetPosterProviders(w.providers)
+	}
+
+	if item == nil {
+		item = container.NewTabItem(filepath.Base(root), grid)
+		w.docTabs.Append(item)
+	} else {
+		item.Text = filepath.Base(root)
+		item.Content = grid
+		w.docTabs.Refresh()
+	}
+	w.docTabs.Select(item)
+
+	tab := &LibraryTab{Root: root, Tree: fileTree, Grid: grid, item: item, cancel: cancel}
+	w.tabs = append(w.tabs, tab)
+	w.activateTab(tab)
+	return tab
+}
+
+// promptNewRoot backs docTabs.CreateTab. It must return a *TabItem synchronously,
+// so it appends a placeholder tab immediately and swaps in the real grid once the
+// (asynchronous) folder picker returns.
+func (w *LibraryWorkspace) promptNewRoot() *container.TabItem {
+	item := container.NewTabItem("New Tab", container.NewCenter())
+
+	dialog.ShowFolderOpen(func(uri fyne.ListableURI, err error) {
+		if err != nil || uri == nil {
+			w.docTabs.Remove(item)
+			return
+		}
+		w.addRootInto(item, uri.Path())
+	}, w.window)
+
+	return item
+}
+
+// closeTab backs docTabs.OnClosed: it cancels the closed tab's tree load context,
+// drains any in-flight poster loads, and evicts its entries from the image cache
+// before dropping the tab.
+func (w *LibraryWorkspace) closeTab(item *container.TabItem) {
+	for i, t := range w.tabs {
+		if t.item != item {
+			continue
+		}
+
+		t.Grid.Close()
+		t.cancel()
+		w.imgCache.EvictPrefix(t.Root)
+
+		w.tabs = append(w.tabs[:i], w.tabs[i+1:]...)
+		if w.active == t {
+			w.active = nil
+			if len(w.tabs) > 0 {
+				w.activateTab(w.tabs[len(w.tabs)-1])
+			}
+		}
+		return
+	}
+}
+
+// selectTab backs docTabs.OnSelected, keeping TypedKey and keyboard shortcuts routed
+// to whichever grid is now in front.
+func (w *LibraryWorkspace) selectTab(item *container.TabItem) {
+	for _, t := range w.tabs {
+		if t.item == item {
+			w.activateTab(t)
+			return
+		}
+	}
+}
+
+// activateTab deactivates the previously active tab's canvas shortcuts (if any) and
+// activates t's, since Fyne's shortcut table is keyed per-window, not per-tab: without
+// this, every tab's MediaGrid would register its Key+Mod bindings on the same shared
+// canvas and only the most-recently-activated tab's would still fire.
+func (w *LibraryWorkspace) activateTab(t *LibraryTab) {
+	if w.active == t {
+		return
+	}
+	if w.active != nil {
+		w.active.Grid.DeactivateShortcuts()
+	}
+	t.Grid.ActivateShortcuts()
+	w.active = t
+}